@@ -0,0 +1,50 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"fmt"
+	"io"
+	"iter"
+)
+
+// Debug returns an iterator which yields every element yielded by seq
+// unchanged, and which also writes each element to w as a line of the
+// form "label[index]: value" (using %v). Write errors are ignored. This
+// allows a stage of a pipeline to be watched without restructuring it.
+//
+//	evens := Debug(Filter(numbers, isEven), "evens", os.Stderr)
+//
+// See also [DebugX]
+func Debug[E any](seq iter.Seq[E], label string, w io.Writer) iter.Seq[E] {
+	return DebugX(seq, label, w, nil, 1)
+}
+
+// DebugX is like [Debug] except that it uses the format function to
+// convert elements to text (or %v if format is nil), and only writes every
+// every'th element (the first, then the every+1'th, and so on), so that
+// large volumes can be sampled.
+// DebugX panics if every is <= 0.
+func DebugX[E any](seq iter.Seq[E], label string, w io.Writer,
+	format func(E) string, every int,
+) iter.Seq[E] {
+	if every <= 0 {
+		panic("every must be > 0")
+	}
+	return func(yield func(E) bool) {
+		i := 0
+		for element := range seq {
+			if i%every == 0 {
+				if format == nil {
+					fmt.Fprintf(w, "%s[%d]: %v\n", label, i, element)
+				} else {
+					fmt.Fprintf(w, "%s[%d]: %s\n", label, i, format(element))
+				}
+			}
+			i++
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
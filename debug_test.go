@@ -0,0 +1,32 @@
+package ufunc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_Debug(t *testing.T) {
+	var out strings.Builder
+	seq := Debug(slices.Values([]string{"a", "b", "c"}), "letters", &out)
+	if got := slices.Collect(seq); !slices.Equal(got, []string{"a", "b",
+		"c"}) {
+		t.Errorf("expected [a b c], got %v", got)
+	}
+	exp := "letters[0]: a\nletters[1]: b\nletters[2]: c\n"
+	if out.String() != exp {
+		t.Errorf("expected %q, got %q", exp, out.String())
+	}
+	out.Reset()
+	hex := func(n int) string { return fmt.Sprintf("%#x", n) }
+	for n := range DebugX(RangeX(10, 20, 1), "n", &out, hex, 4) {
+		if n == 18 {
+			break
+		}
+	}
+	exp = "n[0]: 0xa\nn[4]: 0xe\nn[8]: 0x12\n"
+	if out.String() != exp {
+		t.Errorf("expected %q, got %q", exp, out.String())
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import "iter"
+
+// DefaultMap is a generic map which creates a value (using the factory
+// function it was created with) whenever a missing key is accessed with
+// [DefaultMap.Get]. The zero value is an empty map ready to use whose
+// default values are V's zero value. Iteration order is unspecified.
+type DefaultMap[K comparable, V any] struct {
+	values  map[K]V
+	factory func() V
+}
+
+// NewDefaultMap returns a new empty DefaultMap which uses the factory
+// function to create values for missing keys.
+//
+//	counts := NewDefaultMap[string](func() int { return 0 })
+//	counts.Set(word, counts.Get(word)+1)
+//	groups := NewDefaultMap[int](func() *[]string { return &[]string{} })
+//	*groups.Get(len(word)) = append(*groups.Get(len(word)), word)
+func NewDefaultMap[K comparable, V any](factory func() V) *DefaultMap[K, V] {
+	return &DefaultMap[K, V]{values: map[K]V{}, factory: factory}
+}
+
+// All returns an iterator which yields every key-value pair in the map.
+func (me *DefaultMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range me.values {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// Contains returns true if the key is in the map. It never creates a
+// value.
+func (me *DefaultMap[K, V]) Contains(key K) bool {
+	_, ok := me.values[key]
+	return ok
+}
+
+// Delete removes the key and its value from the map (doing nothing if the
+// key isn't present).
+func (me *DefaultMap[K, V]) Delete(key K) { delete(me.values, key) }
+
+// Get returns the value for the given key. If the key isn't present, a new
+// value is created, stored, and returned.
+// See also [DefaultMap.Peek]
+func (me *DefaultMap[K, V]) Get(key K) V {
+	value, ok := me.values[key]
+	if !ok {
+		if me.factory != nil {
+			value = me.factory()
+		}
+		me.Set(key, value)
+	}
+	return value
+}
+
+// Len returns the number of key-value pairs in the map.
+func (me *DefaultMap[K, V]) Len() int { return len(me.values) }
+
+// Peek returns the value for the given key and true, or the zero value and
+// false if the key isn't present. Unlike [DefaultMap.Get] it never creates
+// a value.
+func (me *DefaultMap[K, V]) Peek(key K) (V, bool) {
+	value, ok := me.values[key]
+	return value, ok
+}
+
+// Set sets the value for the given key.
+func (me *DefaultMap[K, V]) Set(key K, value V) {
+	if me.values == nil {
+		me.values = map[K]V{}
+	}
+	me.values[key] = value
+}
@@ -0,0 +1,43 @@
+package ufunc
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_DefaultMap(t *testing.T) {
+	counts := NewDefaultMap[string](func() int { return 0 })
+	for _, word := range strings.Fields("a b a c a b") {
+		counts.Set(word, counts.Get(word)+1)
+	}
+	exp := map[string]int{"a": 3, "b": 2, "c": 1}
+	if got := maps.Collect(counts.All()); !maps.Equal(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if v, ok := counts.Peek("d"); ok || v != 0 || counts.Contains("d") {
+		t.Errorf("expected Peek not to create, got %d %t", v, ok)
+	}
+	if v := counts.Get("d"); v != 0 || !counts.Contains("d") ||
+		counts.Len() != 4 {
+		t.Errorf("expected Get to create, got %d %d", v, counts.Len())
+	}
+	counts.Delete("d")
+	if counts.Len() != 3 {
+		t.Errorf("expected 3, got %d", counts.Len())
+	}
+	groups := NewDefaultMap[int](func() *[]string { return &[]string{} })
+	for _, word := range strings.Fields("one two three four five six") {
+		group := groups.Get(len(word))
+		*group = append(*group, word)
+	}
+	if got := *groups.Get(3); !slices.Equal(got, []string{"one", "two",
+		"six"}) {
+		t.Errorf("expected [one two six], got %v", got)
+	}
+	var zero DefaultMap[string, float64]
+	if v := zero.Get("x"); v != 0 || zero.Len() != 1 {
+		t.Errorf("expected 0 1, got %g %d", v, zero.Len())
+	}
+}
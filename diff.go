@@ -0,0 +1,190 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import "errors"
+
+// ErrPatchMismatch is returned by [ApplyPatch] if the edits don't apply to
+// the given slice.
+var ErrPatchMismatch = errors.New("patch doesn't match")
+
+// EditOp is the kind of an [Edit].
+type EditOp uint8
+
+const (
+	// EditEqual means the value is in both the old and new slices.
+	EditEqual EditOp = iota
+	// EditDelete means the value is only in the old slice.
+	EditDelete
+	// EditInsert means the value is only in the new slice.
+	EditInsert
+)
+
+// String returns "=", "-", or "+" for EditEqual, EditDelete, and
+// EditInsert respectively.
+func (me EditOp) String() string {
+	switch me {
+	case EditDelete:
+		return "-"
+	case EditInsert:
+		return "+"
+	default:
+		return "="
+	}
+}
+
+// Edit is a single edit operation as returned by [DiffSlices].
+type Edit[E any] struct {
+	Op    EditOp
+	Value E
+}
+
+// ApplyPatch returns a new slice produced by applying the edits (as
+// returned by [DiffSlices]) to the values slice. Returns nil and
+// [ErrPatchMismatch] if the edits' equal and deleted values don't match the
+// values slice.
+func ApplyPatch[E comparable](values []E, edits []Edit[E]) ([]E, error) {
+	patched := make([]E, 0, len(values))
+	i := 0
+	for _, edit := range edits {
+		switch edit.Op {
+		case EditInsert:
+			patched = append(patched, edit.Value)
+		default:
+			if i == len(values) || values[i] != edit.Value {
+				return nil, ErrPatchMismatch
+			}
+			if edit.Op == EditEqual {
+				patched = append(patched, edit.Value)
+			}
+			i++
+		}
+	}
+	if i != len(values) {
+		return nil, ErrPatchMismatch
+	}
+	return patched, nil
+}
+
+// DiffSlices returns a minimal sequence of edits which transforms slice a
+// into slice b, based on their longest common subsequence. Deletions are
+// given before insertions at the same position.
+// See also [ApplyPatch]
+func DiffSlices[E comparable](a, b []E) []Edit[E] {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	edits := make([]Edit[E], 0, len(a)+len(b)-prefix-suffix)
+	for _, x := range a[:prefix] {
+		edits = append(edits, Edit[E]{EditEqual, x})
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	table := lcsTable(midA, midB)
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			edits = append(edits, Edit[E]{EditEqual, midA[i]})
+			i++
+			j++
+		case j == len(midB) ||
+			i < len(midA) && table[i+1][j] >= table[i][j+1]:
+			edits = append(edits, Edit[E]{EditDelete, midA[i]})
+			i++
+		default:
+			edits = append(edits, Edit[E]{EditInsert, midB[j]})
+			j++
+		}
+	}
+	for _, x := range a[len(a)-suffix:] {
+		edits = append(edits, Edit[E]{EditEqual, x})
+	}
+	return edits
+}
+
+// LCS returns a new slice containing a longest common subsequence of a and
+// b, i.e., the longest sequence of elements that occur in both in the same
+// order (though not necessarily contiguously).
+// See also [DiffSlices]
+func LCS[E comparable](a, b []E) []E {
+	table := lcsTable(a, b)
+	common := make([]E, 0, table[0][0])
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			common = append(common, a[i])
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return common
+}
+
+// Levenshtein returns the edit distance between a and b, i.e., the minimum
+// number of single element insertions, deletions, and substitutions needed
+// to turn a into b.
+// See also [LevenshteinCost]
+func Levenshtein[E comparable](a, b []E) int {
+	one := func(E) int { return 1 }
+	return LevenshteinCost(a, b, one, one, func(x, y E) int {
+		if x == y {
+			return 0
+		}
+		return 1
+	})
+}
+
+// LevenshteinCost returns the weighted edit distance between a and b,
+// i.e., the minimum total cost of insertions, removals (deletions), and
+// substitutions needed to turn a into b, using the given cost functions.
+// The substitute function must return 0 for elements that are considered
+// equal.
+// See also [Levenshtein]
+func LevenshteinCost[E any](a, b []E, insert, remove func(E) int,
+	substitute func(E, E) int,
+) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j, y := range b {
+		previous[j+1] = previous[j] + insert(y)
+	}
+	for _, x := range a {
+		current[0] = previous[0] + remove(x)
+		for j, y := range b {
+			current[j+1] = min(previous[j+1]+remove(x), current[j]+insert(y),
+				previous[j]+substitute(x, y))
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// lcsTable returns a table where table[i][j] is the length of the longest
+// common subsequence of a[i:] and b[j:].
+func lcsTable[E comparable](a, b []E) [][]int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+	return table
+}
@@ -0,0 +1,81 @@
+package ufunc
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_DiffSlices(t *testing.T) {
+	a := strings.Fields("the quick brown fox jumps over the lazy dog")
+	b := strings.Fields("the quick red fox leaps over the dog today")
+	edits := DiffSlices(a, b)
+	var parts []string
+	for _, edit := range edits {
+		parts = append(parts, edit.Op.String()+edit.Value)
+	}
+	exp := "=the =quick -brown +red =fox -jumps +leaps =over =the -lazy " +
+		"=dog +today"
+	if got := strings.Join(parts, " "); got != exp {
+		t.Errorf("expected %s; got %s", exp, got)
+	}
+	patched, err := ApplyPatch(a, edits)
+	if err != nil || slices.Compare(patched, b) != 0 {
+		t.Errorf("expected %v <nil>; got %v %v", b, patched, err)
+	}
+	if _, err := ApplyPatch(b, edits); err != ErrPatchMismatch {
+		t.Errorf("expected ErrPatchMismatch; got %v", err)
+	}
+	if _, err := ApplyPatch(append(a, "x"), edits); err != ErrPatchMismatch {
+		t.Errorf("expected ErrPatchMismatch; got %v", err)
+	}
+	for _, x := range [][2][]int{{{}, {}}, {{1, 2}, {}}, {{}, {1, 2}},
+		{{1, 2, 3}, {1, 2, 3}}, {{1, 2, 3, 4}, {2, 4, 5}}} {
+		patched, err := ApplyPatch(x[0], DiffSlices(x[0], x[1]))
+		if err != nil || slices.Compare(patched, x[1]) != 0 {
+			t.Errorf("expected %v <nil>; got %v %v", x[1], patched, err)
+		}
+	}
+}
+
+func Test_LCS(t *testing.T) {
+	got := string(LCS([]rune("AGGTAB"), []rune("GXTXAYB")))
+	if got != "GTAB" {
+		t.Errorf("expected GTAB; got %s", got)
+	}
+	if got := LCS([]int{1, 2}, []int{3}); len(got) != 0 {
+		t.Errorf("expected []; got %v", got)
+	}
+}
+
+func Test_Levenshtein(t *testing.T) {
+	for _, x := range []struct {
+		a, b string
+		exp  int
+	}{
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"flaw", "lawn", 2},
+		{"same", "same", 0},
+	} {
+		if got := Levenshtein([]rune(x.a), []rune(x.b)); got != x.exp {
+			t.Errorf("%s→%s expected %d; got %d", x.a, x.b, x.exp, got)
+		}
+	}
+	cheapCase := func(x, y rune) int {
+		switch {
+		case x == y:
+			return 0
+		case strings.EqualFold(string(x), string(y)):
+			return 1
+		default:
+			return 10
+		}
+	}
+	ten := func(rune) int { return 10 }
+	if got := LevenshteinCost([]rune("Hello"), []rune("hELLO"), ten, ten,
+		cheapCase); got != 5 {
+		t.Errorf("expected 5; got %d", got)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"sync"
+)
+
+// AndP returns a predicate that returns true if all of the given
+// predicates return true (short-circuiting at the first that returns
+// false). With no predicates it always returns true.
+// See also [OrP] and [Not]
+func AndP[E any](predicates ...func(E) bool) func(E) bool {
+	return func(x E) bool {
+		for _, predicate := range predicates {
+			if !predicate(x) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Compose2 returns a function that calls f and then calls g on the result,
+// i.e., Compose2(f, g)(x) == g(f(x)).
+// See also [Compose3] and [Pipe]
+func Compose2[A, B, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(a A) C { return g(f(a)) }
+}
+
+// Compose3 returns a function that calls f, then g on the result, then h
+// on that result, i.e., Compose3(f, g, h)(x) == h(g(f(x))).
+// See also [Compose2]
+func Compose3[A, B, C, D any](f func(A) B, g func(B) C,
+	h func(C) D,
+) func(A) D {
+	return func(a A) D { return h(g(f(a))) }
+}
+
+// Constantly returns a function that ignores its argument and always
+// returns value.
+func Constantly[E, T any](value T) func(E) T {
+	return func(E) T { return value }
+}
+
+// Flip returns a function like f but which takes its two arguments in the
+// opposite order, i.e., Flip(f)(b, a) == f(a, b).
+func Flip[A, B, R any](f func(A, B) R) func(B, A) R {
+	return func(b B, a A) R { return f(a, b) }
+}
+
+// GenerateN returns a new slice of count elements where element i is the
+// result of calling generate(i).
+// GenerateN panics if count is negative.
+// See also [Times]
+func GenerateN[T any](count int, generate func(int) T) []T {
+	if count < 0 {
+		panic("count must be >= 0")
+	}
+	generated := make([]T, count)
+	for i := range generated {
+		generated[i] = generate(i)
+	}
+	return generated
+}
+
+// Identity returns its argument unchanged.
+func Identity[E any](x E) E { return x }
+
+// Lazy returns a function which calls f the first time it is called and
+// thereafter returns the same result without calling f again. It is safe
+// to use from multiple goroutines.
+// See also [LazySeq] and [sync.OnceValue]
+func Lazy[T any](f func() T) func() T { return sync.OnceValue(f) }
+
+// LazySeq returns an iterator which calls f to create the underlying
+// iterator only when iteration first starts, e.g., so that an expensive
+// source (a file, a database cursor) is only opened if it is actually
+// consumed. f is called at most once; later iterations reuse the same
+// underlying iterator.
+// See also [Lazy]
+func LazySeq[E any](f func() iter.Seq[E]) iter.Seq[E] {
+	seq := sync.OnceValue(f)
+	return func(yield func(E) bool) {
+		for element := range seq() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Not returns a predicate that returns the opposite of the given
+// predicate.
+// See also [AndP] and [OrP]
+func Not[E any](predicate func(E) bool) func(E) bool {
+	return func(x E) bool { return !predicate(x) }
+}
+
+// OrP returns a predicate that returns true if any of the given predicates
+// return true (short-circuiting at the first that returns true). With no
+// predicates it always returns false.
+// See also [AndP] and [Not]
+func OrP[E any](predicates ...func(E) bool) func(E) bool {
+	return func(x E) bool {
+		for _, predicate := range predicates {
+			if predicate(x) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Partial2 returns a function of one argument that calls f with a as its
+// first argument, i.e., Partial2(f, a)(b) == f(a, b). This is useful for
+// adapting two-argument functions to the one-argument shape expected by
+// functions such as [Filter].
+// See also [Partial3]
+func Partial2[A, B, R any](f func(A, B) R, a A) func(B) R {
+	return func(b B) R { return f(a, b) }
+}
+
+// Partial3 returns a function of two arguments that calls f with a as its
+// first argument, i.e., Partial3(f, a)(b, c) == f(a, b, c).
+// See also [Partial2]
+func Partial3[A, B, C, R any](f func(A, B, C) R, a A) func(B, C) R {
+	return func(b B, c C) R { return f(a, b, c) }
+}
+
+// Pipe returns a function that applies each of the given pipeline stages
+// in turn, so that a reusable pipeline can be defined once and applied to
+// many sources. With no stages the source is returned unchanged.
+//
+//	firstEvens := Pipe(
+//		func(s iter.Seq[int]) iter.Seq[int] { return Filter(s, isEven) },
+//		func(s iter.Seq[int]) iter.Seq[int] { return Take(s, 3) })
+//	for x := range firstEvens(Range(1, 100)) { // 2 4 6
+func Pipe[E any](stages ...func(iter.Seq[E]) iter.Seq[E],
+) func(iter.Seq[E]) iter.Seq[E] {
+	return func(seq iter.Seq[E]) iter.Seq[E] {
+		for _, stage := range stages {
+			seq = stage(seq)
+		}
+		return seq
+	}
+}
+
+// Times calls f count times, passing it 0, 1, …, count-1.
+// See also [GenerateN]
+func Times(count int, f func(int)) {
+	for i := range count {
+		f(i)
+	}
+}
@@ -0,0 +1,133 @@
+package ufunc
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_Compose(t *testing.T) {
+	double := func(i int) int { return i * 2 }
+	describe := Compose2(double, strconv.Itoa)
+	if got := describe(21); got != "42" {
+		t.Errorf("expected 42; got %s", got)
+	}
+	size := Compose3(double, strconv.Itoa, func(s string) int {
+		return len(s)
+	})
+	if got := size(500); got != 4 {
+		t.Errorf("expected 4; got %d", got)
+	}
+}
+
+func Test_Pipe(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+	firstEvens := Pipe(
+		func(s iter.Seq[int]) iter.Seq[int] { return Filter(s, isEven) },
+		func(s iter.Seq[int]) iter.Seq[int] { return Take(s, 3) })
+	got := slices.Collect(firstEvens(Range(1, 100)))
+	exp := []int{2, 4, 6}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = slices.Collect(firstEvens(Range(11, 15)))
+	exp = []int{12, 14}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = slices.Collect(Pipe[int]()(Range(1, 3)))
+	exp = []int{1, 2}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+}
+
+func Test_Partial(t *testing.T) {
+	hasPrefix := Flip(strings.HasPrefix) // (prefix, s)
+	isTest := Partial2(hasPrefix, "Test")
+	got := slices.Collect(Filter(slices.Values([]string{"TestA", "Bench",
+		"Testing"}), isTest))
+	exp := []string{"TestA", "Testing"}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	replaceX := Partial3(strings.ReplaceAll, "xox")
+	if got := replaceX("x", "-"); got != "-o-" {
+		t.Errorf("expected -o-; got %s", got)
+	}
+}
+
+func Test_Predicates(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+	isSmall := func(i int) bool { return i < 5 }
+	data := slices.Collect(Range(0, 10))
+	for _, x := range []struct {
+		name string
+		keep func(int) bool
+		exp  []int
+	}{
+		{"Not", Not(isEven), []int{1, 3, 5, 7, 9}},
+		{"AndP", AndP(isEven, isSmall), []int{0, 2, 4}},
+		{"OrP", OrP(isEven, isSmall), []int{0, 1, 2, 3, 4, 6, 8}},
+		{"AndP()", AndP[int](), data},
+		{"OrP()", OrP[int](), []int{}},
+		{"Constantly", Constantly[int](false), []int{}},
+	} {
+		if got := FilterSlice(data, x.keep); slices.Compare(x.exp,
+			got) != 0 {
+			t.Errorf("%s: expected %v; got %v", x.name, x.exp, got)
+		}
+	}
+	if got := Identity("x"); got != "x" {
+		t.Errorf("expected x; got %s", got)
+	}
+}
+
+func Test_GenerateN(t *testing.T) {
+	got := GenerateN(5, func(i int) int { return i * i })
+	exp := []int{0, 1, 4, 9, 16}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	var names []string
+	Times(3, func(i int) { names = append(names, strconv.Itoa(i)) })
+	expn := []string{"0", "1", "2"}
+	if slices.Compare(expn, names) != 0 {
+		t.Errorf("expected %v; got %v", expn, names)
+	}
+	Times(-1, func(int) { t.Error("unexpected call") })
+}
+
+func Test_Lazy(t *testing.T) {
+	calls := 0
+	value := Lazy(func() string {
+		calls++
+		return "expensive"
+	})
+	if calls != 0 {
+		t.Errorf("expected 0 calls, got %d", calls)
+	}
+	if got := value() + value(); got != "expensiveexpensive" || calls != 1 {
+		t.Errorf("expected expensiveexpensive 1, got %s %d", got, calls)
+	}
+	opened := 0
+	seq := LazySeq(func() iter.Seq[int] {
+		opened++
+		return slices.Values([]int{1, 2, 3})
+	})
+	if opened != 0 {
+		t.Errorf("expected 0 opens, got %d", opened)
+	}
+	for x := range seq {
+		if x != 1 {
+			t.Errorf("expected 1, got %d", x)
+		}
+		break
+	}
+	if got := slices.Collect(seq); !slices.Equal(got, []int{1, 2, 3}) ||
+		opened != 1 {
+		t.Errorf("expected [1 2 3] 1, got %v %d", got, opened)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+)
+
+// ErrClosed is yielded by [Generator.All] if the generator has been
+// closed.
+var ErrClosed = errors.New("generator closed")
+
+// Generator is a resource-owning source of elements (e.g., one that reads
+// a file or a database cursor) whose producer function runs in its own
+// goroutine and is always finished (and so has released its resources) by
+// the time iteration ends, whether the consumer reached the end, broke out
+// early, or the generator was closed. Create a Generator using
+// [NewGenerator]. It is safe to use from multiple goroutines.
+type Generator[E any] struct {
+	producer func(context.Context, func(E) error) error
+	ctx      context.Context
+	cancel   context.CancelFunc
+	mutex    sync.Mutex
+	closed   bool
+	running  sync.WaitGroup
+}
+
+// NewGenerator returns a new Generator which uses the producer function to
+// create its elements. The producer must pass each element to emit in
+// turn, and must stop (releasing any resources) and return as soon as emit
+// returns an error (which it does if the consumer has stopped or the
+// generator has been closed), or as soon as its context is done.
+//
+//	lines := NewGenerator(func(ctx context.Context,
+//		emit func(string) error) error {
+//		file, err := os.Open(filename)
+//		if err != nil {
+//			return err
+//		}
+//		defer file.Close()
+//		scanner := bufio.NewScanner(file)
+//		for scanner.Scan() {
+//			if err := emit(scanner.Text()); err != nil {
+//				return err
+//			}
+//		}
+//		return scanner.Err()
+//	})
+//	defer lines.Close()
+func NewGenerator[E any](producer func(ctx context.Context,
+	emit func(E) error) error,
+) *Generator[E] {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Generator[E]{producer: producer, ctx: ctx, cancel: cancel}
+}
+
+// All returns an iterator which runs the producer and yields each element
+// it emits, in order, with a nil error. If the producer returns an error,
+// a final zero value and the error are yielded. If the generator is (or
+// becomes) closed, a final zero value and [ErrClosed] are yielded. Each
+// call to All runs the producer afresh; the iterator doesn't return until
+// the producer has returned.
+func (me *Generator[E]) All() iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		var zero E
+		me.mutex.Lock()
+		if me.closed {
+			me.mutex.Unlock()
+			yield(zero, ErrClosed)
+			return
+		}
+		me.running.Add(1)
+		me.mutex.Unlock()
+		defer me.running.Done()
+		ctx, cancel := context.WithCancel(me.ctx)
+		values := make(chan E)
+		result := make(chan error, 1)
+		go func() {
+			defer close(values)
+			result <- me.producer(ctx, func(element E) error {
+				select {
+				case values <- element:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+		}()
+		defer func() {
+			cancel()
+			for range values { // wait for the producer to finish
+			}
+		}()
+		for element := range values {
+			if !yield(element, nil) {
+				return
+			}
+		}
+		if err := <-result; me.ctx.Err() != nil {
+			yield(zero, ErrClosed)
+		} else if err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// Close stops any running producers, waits for them to finish, and
+// prevents any further iteration. It is safe to call more than once.
+// It always returns nil (so that Generator is an [io.Closer]). Since it
+// waits for iterations to finish it mustn't be called from inside a range
+// over [Generator.All] in the same goroutine (break out instead).
+func (me *Generator[E]) Close() error {
+	me.mutex.Lock()
+	me.closed = true
+	me.cancel()
+	me.mutex.Unlock()
+	me.running.Wait()
+	return nil
+}
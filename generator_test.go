@@ -0,0 +1,89 @@
+package ufunc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func counter(limit int, open *atomic.Int32) func(context.Context,
+	func(int) error) error {
+	return func(ctx context.Context, emit func(int) error) error {
+		open.Add(1)
+		defer open.Add(-1)
+		for i := range limit {
+			if err := emit(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func Test_Generator(t *testing.T) {
+	var open atomic.Int32
+	gen := NewGenerator(counter(5, &open))
+	values := []int{}
+	for value, err := range gen.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, value)
+	}
+	if len(values) != 5 || values[4] != 4 {
+		t.Errorf("expected [0 1 2 3 4], got %v", values)
+	}
+	for value := range gen.All() {
+		if value == 2 {
+			break
+		}
+	}
+	if n := open.Load(); n != 0 {
+		t.Errorf("expected producer finished after break, got %d open", n)
+	}
+	errBad := errors.New("bad")
+	failing := NewGenerator(func(ctx context.Context,
+		emit func(string) error) error {
+		if err := emit("ok"); err != nil {
+			return err
+		}
+		return errBad
+	})
+	var errs []error
+	for _, err := range failing.All() {
+		errs = append(errs, err)
+	}
+	if len(errs) != 2 || errs[0] != nil || !errors.Is(errs[1], errBad) {
+		t.Errorf("expected [<nil> bad], got %v", errs)
+	}
+}
+
+func Test_GeneratorClose(t *testing.T) {
+	var open atomic.Int32
+	gen := NewGenerator(counter(1_000_000, &open))
+	var last error
+	count := 0
+	for _, err := range gen.All() {
+		if err != nil {
+			last = err
+			break
+		}
+		count++
+		if count == 10 {
+			go gen.Close()
+		}
+	}
+	if !errors.Is(last, ErrClosed) || open.Load() != 0 {
+		t.Errorf("expected ErrClosed with 0 open, got %v %d", last,
+			open.Load())
+	}
+	if err := gen.Close(); err != nil {
+		t.Error(err)
+	}
+	for _, err := range gen.All() {
+		if !errors.Is(err, ErrClosed) {
+			t.Errorf("expected ErrClosed, got %v", err)
+		}
+	}
+}
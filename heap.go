@@ -0,0 +1,112 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import "iter"
+
+// Heap is a generic binary heap (priority queue) ordered by a less
+// function: the element for which less returns true against all others is
+// at the top. Use a less function of a < b for a min-heap or a > b for a
+// max-heap. Create a Heap using [NewHeap] or [HeapFromSeq].
+type Heap[E any] struct {
+	elements []E
+	less     func(a, b E) bool
+}
+
+// NewHeap returns a new empty Heap ordered by the less function.
+func NewHeap[E any](less func(a, b E) bool) *Heap[E] {
+	return &Heap[E]{less: less}
+}
+
+// HeapFromSeq returns a new Heap ordered by the less function and
+// containing all the elements yielded by seq. The heap is built in O(n).
+func HeapFromSeq[E any](seq iter.Seq[E], less func(a, b E) bool) *Heap[E] {
+	heap := &Heap[E]{less: less}
+	for element := range seq {
+		heap.elements = append(heap.elements, element)
+	}
+	for i := len(heap.elements)/2 - 1; i >= 0; i-- {
+		heap.down(i)
+	}
+	return heap
+}
+
+// Drain returns an iterator which pops and yields the heap's elements in
+// priority order until the heap is empty (or the iteration is stopped).
+func (me *Heap[E]) Drain() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for len(me.elements) > 0 {
+			element, _ := me.Pop()
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of elements in the heap.
+func (me *Heap[E]) Len() int { return len(me.elements) }
+
+// Peek returns the top element and true without removing it, or the zero
+// value and false if the heap is empty.
+func (me *Heap[E]) Peek() (E, bool) {
+	if len(me.elements) == 0 {
+		var zero E
+		return zero, false
+	}
+	return me.elements[0], true
+}
+
+// Pop removes and returns the top element and true, or the zero value and
+// false if the heap is empty.
+func (me *Heap[E]) Pop() (E, bool) {
+	var zero E
+	if len(me.elements) == 0 {
+		return zero, false
+	}
+	top := me.elements[0]
+	last := len(me.elements) - 1
+	me.elements[0] = me.elements[last]
+	me.elements[last] = zero
+	me.elements = me.elements[:last]
+	me.down(0)
+	return top, true
+}
+
+// Push adds the element to the heap.
+func (me *Heap[E]) Push(element E) {
+	me.elements = append(me.elements, element)
+	me.up(len(me.elements) - 1)
+}
+
+func (me *Heap[E]) down(i int) {
+	for {
+		best := i
+		for _, child := range [2]int{2*i + 1, 2*i + 2} {
+			if child < len(me.elements) &&
+				me.less(me.elements[child], me.elements[best]) {
+				best = child
+			}
+		}
+		if best == i {
+			return
+		}
+		me.swap(i, best)
+		i = best
+	}
+}
+
+func (me *Heap[E]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !me.less(me.elements[i], me.elements[parent]) {
+			return
+		}
+		me.swap(i, parent)
+		i = parent
+	}
+}
+
+func (me *Heap[E]) swap(i, j int) {
+	me.elements[i], me.elements[j] = me.elements[j], me.elements[i]
+}
@@ -0,0 +1,38 @@
+package ufunc
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_Heap(t *testing.T) {
+	heap := NewHeap(func(a, b int) bool { return a < b })
+	if _, ok := heap.Pop(); ok {
+		t.Error("expected false for empty heap")
+	}
+	for _, x := range []int{5, 2, 8, 1, 9, 3, 2} {
+		heap.Push(x)
+	}
+	if top, ok := heap.Peek(); top != 1 || !ok || heap.Len() != 7 {
+		t.Errorf("expected 1 true 7; got %d %t %d", top, ok, heap.Len())
+	}
+	got := slices.Collect(heap.Drain())
+	exp := []int{1, 2, 2, 3, 5, 8, 9}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	maxHeap := HeapFromSeq(slices.Values([]string{"b", "d", "a", "c"}),
+		func(a, b string) bool { return a > b })
+	if top, _ := maxHeap.Pop(); top != "d" {
+		t.Errorf("expected d; got %s", top)
+	}
+	for s := range maxHeap.Drain() {
+		if s != "c" {
+			t.Errorf("expected c; got %s", s)
+		}
+		break
+	}
+	if maxHeap.Len() != 2 {
+		t.Errorf("expected 2; got %d", maxHeap.Len())
+	}
+}
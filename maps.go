@@ -0,0 +1,254 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// ErrDuplicate is returned (wrapped) when a transformation would map two
+// different keys to the same new key, e.g., by [Invert].
+var ErrDuplicate = errors.New("duplicate key")
+
+// FilterMap returns a new map containing only those key-value pairs of m
+// for which keep returns true.
+// See also [maps.DeleteFunc]
+func FilterMap[K comparable, V any](m map[K]V, keep func(K, V) bool,
+) map[K]V {
+	filtered := make(map[K]V)
+	for key, value := range m {
+		if keep(key, value) {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// Invert returns a new map whose keys are m's values and whose values are
+// m's keys. If two keys have the same value, Invert returns a nil map and
+// an error wrapping [ErrDuplicate].
+// See also [InvertFunc] and [InvertMulti]
+func Invert[K, V comparable](m map[K]V) (map[V]K, error) {
+	inverted := make(map[V]K, len(m))
+	for key, value := range m {
+		if _, ok := inverted[value]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicate, value)
+		}
+		inverted[value] = key
+	}
+	return inverted, nil
+}
+
+// InvertFunc returns a new map whose keys are m's values and whose values
+// are m's keys. If two keys have the same value, resolve is called with
+// the value and the two keys and its result is used. Since map iteration
+// order is unspecified, resolve should not depend on argument order (e.g.,
+// it could return the smaller key).
+// See also [Invert] and [InvertMulti]
+func InvertFunc[K, V comparable](m map[K]V, resolve func(value V, a, b K) K,
+) map[V]K {
+	inverted := make(map[V]K, len(m))
+	for key, value := range m {
+		if old, ok := inverted[value]; ok {
+			key = resolve(value, old, key)
+		}
+		inverted[value] = key
+	}
+	return inverted
+}
+
+// InvertMulti returns a new map whose keys are m's values and whose values
+// are slices of all the keys that had that value, in unspecified order.
+// See also [Invert] and [InvertFunc]
+func InvertMulti[K, V comparable](m map[K]V) map[V][]K {
+	inverted := make(map[V][]K, len(m))
+	for key, value := range m {
+		inverted[value] = append(inverted[value], key)
+	}
+	return inverted
+}
+
+// MapKeys returns a new slice of m's keys in unspecified order.
+// See also [SortedKeys] and [maps.Keys]
+func MapKeys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// MapKeysOf returns a new map with the same values as m but with each key
+// replaced by the result of calling transform on it. If two keys transform
+// to the same new key, MapKeysOf returns a nil map and an error wrapping
+// [ErrDuplicate].
+// See also [MapKeysOfFunc] and [MapValuesOf]
+func MapKeysOf[K, K2 comparable, V any](m map[K]V, transform func(K) K2,
+) (map[K2]V, error) {
+	transformed := make(map[K2]V, len(m))
+	for key, value := range m {
+		newKey := transform(key)
+		if _, ok := transformed[newKey]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicate, newKey)
+		}
+		transformed[newKey] = value
+	}
+	return transformed, nil
+}
+
+// MapKeysOfFunc returns a new map with the same values as m but with each
+// key replaced by the result of calling transform on it. If two keys
+// transform to the same new key, resolve is called with the new key and
+// the two values and its result is used. Since map iteration order is
+// unspecified, resolve should not depend on argument order.
+// See also [MapKeysOf]
+func MapKeysOfFunc[K, K2 comparable, V any](m map[K]V, transform func(K) K2,
+	resolve func(key K2, a, b V) V,
+) map[K2]V {
+	transformed := make(map[K2]V, len(m))
+	for key, value := range m {
+		newKey := transform(key)
+		if old, ok := transformed[newKey]; ok {
+			value = resolve(newKey, old, value)
+		}
+		transformed[newKey] = value
+	}
+	return transformed
+}
+
+// MapValues returns a new slice of m's values in unspecified order.
+// See also [MapKeys] and [maps.Values]
+func MapValues[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+	return values
+}
+
+// MapValuesOf returns a new map with the same keys as m but with each
+// value replaced by the result of calling transform on it.
+// See also [MapKeysOf]
+func MapValuesOf[K comparable, V, V2 any](m map[K]V, transform func(V) V2,
+) map[K]V2 {
+	transformed := make(map[K]V2, len(m))
+	for key, value := range m {
+		transformed[key] = transform(value)
+	}
+	return transformed
+}
+
+// MergeMaps returns a new map containing all the key-value pairs from all
+// the maps; if a key is in more than one map, the last map's value is used.
+// See also [MergeMapsFunc] and [MergeMapsDeep]
+func MergeMaps[K comparable, V any](ms ...map[K]V) map[K]V {
+	return MergeMapsFunc(func(_ K, _, b V) V { return b }, ms...)
+}
+
+// MergeMapsDeep returns a new map containing all the key-value pairs from
+// all the maps. If a key is in more than one map and both values are of
+// type map[K]any, they are merged recursively; otherwise the last map's
+// value is used. Nested maps are copied, so the result doesn't share any
+// map[K]any with the arguments.
+// See also [MergeMaps]
+func MergeMapsDeep[K comparable](ms ...map[K]any) map[K]any {
+	merged := make(map[K]any)
+	for _, m := range ms {
+		for key, value := range m {
+			if sub, ok := value.(map[K]any); ok {
+				if old, ok := merged[key].(map[K]any); ok {
+					value = MergeMapsDeep(old, sub)
+				} else {
+					value = MergeMapsDeep(sub)
+				}
+			}
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// MergeMapsFunc returns a new map containing all the key-value pairs from
+// all the maps. If a key is in more than one map, resolve is called with
+// the key, the value so far, and the later map's value, and its result is
+// used.
+// See also [MergeMaps]
+func MergeMapsFunc[K comparable, V any](resolve func(key K, a, b V) V,
+	ms ...map[K]V,
+) map[K]V {
+	size := 0
+	for _, m := range ms {
+		size = max(size, len(m))
+	}
+	merged := make(map[K]V, size)
+	for _, m := range ms {
+		for key, value := range m {
+			if old, ok := merged[key]; ok {
+				value = resolve(key, old, value)
+			}
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// SortedAll returns an iterator over m's key-value pairs in ascending key
+// order, so that output is reproducible. The keys are gathered and sorted
+// when iteration starts.
+// See also [SortedAllByValue], [SortedAllFunc], and [SortedKeys]
+func SortedAll[K cmp.Ordered, V any](m map[K]V) iter.Seq2[K, V] {
+	return SortedAllFunc(m, cmp.Compare[K])
+}
+
+// SortedAllByValue returns an iterator over m's key-value pairs ordered by
+// value, smallest first; pairs with equal values are in key order.
+// See also [SortedAll] and [SortedByValue]
+func SortedAllByValue[K, V cmp.Ordered](m map[K]V) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range SortedByValue(m) {
+			if !yield(key, m[key]) {
+				return
+			}
+		}
+	}
+}
+
+// SortedAllFunc returns an iterator over m's key-value pairs with the keys
+// ordered by the cmp function (which should return < 0 if a < b, 0 if a ==
+// b, and > 0 if a > b).
+// See also [SortedAll]
+func SortedAllFunc[K comparable, V any](m map[K]V, cmp func(a, b K) int,
+) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range slices.SortedFunc(maps.Keys(m), cmp) {
+			if !yield(key, m[key]) {
+				return
+			}
+		}
+	}
+}
+
+// SortedByValue returns a new slice of m's keys ordered by their values,
+// smallest first; keys with equal values are in key order.
+// See also [SortedKeys]
+func SortedByValue[K, V cmp.Ordered](m map[K]V) []K {
+	keys := MapKeys(m)
+	slices.SortFunc(keys, func(a, b K) int {
+		if c := cmp.Compare(m[a], m[b]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	return keys
+}
+
+// SortedKeys returns a new slice of m's keys in ascending order.
+// See also [MapKeys] and [SortedByValue]
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
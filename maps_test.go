@@ -0,0 +1,179 @@
+package ufunc
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_MapKeysValues(t *testing.T) {
+	m := map[string]int{"b": 2, "c": 3, "a": 1}
+	keys := MapKeys(m)
+	slices.Sort(keys)
+	if exp := []string{"a", "b", "c"}; slices.Compare(exp, keys) != 0 {
+		t.Errorf("expected %v, got %v", exp, keys)
+	}
+	values := MapValues(m)
+	slices.Sort(values)
+	if exp := []int{1, 2, 3}; slices.Compare(exp, values) != 0 {
+		t.Errorf("expected %v, got %v", exp, values)
+	}
+	if keys := MapKeys(map[int]int(nil)); keys == nil || len(keys) != 0 {
+		t.Errorf("expected empty non-nil slice, got %#v", keys)
+	}
+}
+
+func Test_SortedKeys(t *testing.T) {
+	m := map[string]int{"pear": 3, "apple": 7, "fig": 3, "date": 1}
+	exp := []string{"apple", "date", "fig", "pear"}
+	if keys := SortedKeys(m); slices.Compare(exp, keys) != 0 {
+		t.Errorf("expected %v, got %v", exp, keys)
+	}
+	exp = []string{"date", "fig", "pear", "apple"}
+	if keys := SortedByValue(m); slices.Compare(exp, keys) != 0 {
+		t.Errorf("expected %v, got %v", exp, keys)
+	}
+}
+
+func Test_Invert(t *testing.T) {
+	inverted, err := Invert(map[string]int{"one": 1, "two": 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inverted) != 2 || inverted[1] != "one" || inverted[2] != "two" {
+		t.Errorf("expected map[1:one 2:two], got %v", inverted)
+	}
+	m := map[string]int{"one": 1, "uno": 1, "two": 2}
+	if inverted, err := Invert(m); !errors.Is(err, ErrDuplicate) ||
+		inverted != nil {
+		t.Errorf("expected nil, ErrDuplicate, got %v, %v", inverted, err)
+	}
+	smaller := InvertFunc(m, func(_ int, a, b string) string {
+		return min(a, b)
+	})
+	if len(smaller) != 2 || smaller[1] != "one" || smaller[2] != "two" {
+		t.Errorf("expected map[1:one 2:two], got %v", smaller)
+	}
+	multi := InvertMulti(m)
+	ones := multi[1]
+	slices.Sort(ones)
+	if exp := []string{"one", "uno"}; slices.Compare(exp, ones) != 0 {
+		t.Errorf("expected %v, got %v", exp, ones)
+	}
+	if exp := []string{"two"}; slices.Compare(exp, multi[2]) != 0 {
+		t.Errorf("expected %v, got %v", exp, multi[2])
+	}
+}
+
+func Test_MergeMaps(t *testing.T) {
+	a := map[string]int{"x": 1, "y": 2}
+	b := map[string]int{"y": 20, "z": 30}
+	merged := MergeMaps(a, nil, b)
+	exp := map[string]int{"x": 1, "y": 20, "z": 30}
+	if !maps.Equal(exp, merged) {
+		t.Errorf("expected %v, got %v", exp, merged)
+	}
+	if a["y"] != 2 {
+		t.Error("expected arguments to be unchanged")
+	}
+	summed := MergeMapsFunc(func(_ string, x, y int) int { return x + y },
+		a, b, a)
+	exp = map[string]int{"x": 2, "y": 24, "z": 30}
+	if !maps.Equal(exp, summed) {
+		t.Errorf("expected %v, got %v", exp, summed)
+	}
+	if merged := MergeMaps[string, int](); merged == nil || len(merged) != 0 {
+		t.Errorf("expected empty map, got %v", merged)
+	}
+}
+
+func Test_MergeMapsDeep(t *testing.T) {
+	defaults := map[string]any{"name": "app", "db": map[string]any{
+		"host": "localhost", "port": 5432}}
+	overrides := map[string]any{"db": map[string]any{"host": "db.example"},
+		"debug": true}
+	merged := MergeMapsDeep(defaults, overrides)
+	if got := fmt.Sprint(merged); got !=
+		"map[db:map[host:db.example port:5432] debug:true name:app]" {
+		t.Errorf("unexpected merge %s", got)
+	}
+	merged["db"].(map[string]any)["port"] = 1
+	if port := defaults["db"].(map[string]any)["port"]; port != 5432 {
+		t.Errorf("expected defaults to be unchanged, got port %v", port)
+	}
+}
+
+func Test_FilterMap(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	even := FilterMap(m, func(_ string, v int) bool { return v%2 == 0 })
+	if exp := map[string]int{"b": 2, "d": 4}; !maps.Equal(exp, even) {
+		t.Errorf("expected %v, got %v", exp, even)
+	}
+	if len(m) != 4 {
+		t.Error("expected argument to be unchanged")
+	}
+}
+
+func Test_MapKeysValuesOf(t *testing.T) {
+	m := map[string]int{"a": 1, "B": 2, "c": 3}
+	doubled := MapValuesOf(m, func(v int) string { return strconv.Itoa(v * 2) })
+	if exp := map[string]string{"a": "2", "B": "4", "c": "6"}; !maps.Equal(
+		exp, doubled) {
+		t.Errorf("expected %v, got %v", exp, doubled)
+	}
+	upper, err := MapKeysOf(m, strings.ToUpper)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := map[string]int{"A": 1, "B": 2, "C": 3}; !maps.Equal(exp,
+		upper) {
+		t.Errorf("expected %v, got %v", exp, upper)
+	}
+	m["b"] = 20
+	if got, err := MapKeysOf(m, strings.ToUpper); !errors.Is(err,
+		ErrDuplicate) || got != nil {
+		t.Errorf("expected nil, ErrDuplicate, got %v, %v", got, err)
+	}
+	summed := MapKeysOfFunc(m, strings.ToUpper,
+		func(_ string, a, b int) int { return a + b })
+	if exp := map[string]int{"A": 1, "B": 22, "C": 3}; !maps.Equal(exp,
+		summed) {
+		t.Errorf("expected %v, got %v", exp, summed)
+	}
+}
+
+func Test_SortedAll(t *testing.T) {
+	m := map[string]int{"pear": 3, "apple": 7, "fig": 3, "date": 1}
+	var out []string
+	for key, value := range SortedAll(m) {
+		out = append(out, fmt.Sprintf("%s=%d", key, value))
+	}
+	if got := strings.Join(out, " "); got !=
+		"apple=7 date=1 fig=3 pear=3" {
+		t.Errorf("unexpected order %q", got)
+	}
+	out = out[:0]
+	for key, value := range SortedAllByValue(m) {
+		out = append(out, fmt.Sprintf("%s=%d", key, value))
+	}
+	if got := strings.Join(out, " "); got !=
+		"date=1 fig=3 pear=3 apple=7" {
+		t.Errorf("unexpected order %q", got)
+	}
+	out = out[:0]
+	for key := range SortedAllFunc(m, func(a, b string) int {
+		return strings.Compare(b, a)
+	}) {
+		out = append(out, key)
+		if len(out) == 2 {
+			break
+		}
+	}
+	if got := strings.Join(out, " "); got != "pear fig" {
+		t.Errorf("unexpected order %q", got)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Memo returns a function which calls f the first time it is given a
+// particular key and thereafter returns the cached result. The cache is
+// unbounded and the returned function is safe to use from multiple
+// goroutines. The lock isn't held while f runs, so f may itself call the
+// memoized function (e.g., for recursion), but concurrent first calls
+// for the same key may each call f.
+// See also [MemoLRU]
+func Memo[K comparable, V any](f func(K) V) func(K) V {
+	var mutex sync.Mutex
+	cache := make(map[K]V)
+	return func(key K) V {
+		mutex.Lock()
+		value, ok := cache[key]
+		mutex.Unlock()
+		if !ok {
+			value = f(key)
+			mutex.Lock()
+			cache[key] = value
+			mutex.Unlock()
+		}
+		return value
+	}
+}
+
+// MemoLRU returns a function like [Memo]'s except that at most capacity
+// results are cached; when the cache is full the least recently used
+// result is discarded.
+// MemoLRU panics if capacity is <= 0.
+// See also [Memo]
+func MemoLRU[K comparable, V any](f func(K) V, capacity int) func(K) V {
+	if capacity <= 0 {
+		panic("capacity must be > 0")
+	}
+	type entry struct {
+		key   K
+		value V
+	}
+	var mutex sync.Mutex
+	order := list.New() // most recently used at the front
+	cache := make(map[K]*list.Element, capacity)
+	return func(key K) V {
+		mutex.Lock()
+		if element, ok := cache[key]; ok {
+			order.MoveToFront(element)
+			value := element.Value.(entry).value
+			mutex.Unlock()
+			return value
+		}
+		mutex.Unlock()
+		value := f(key)
+		mutex.Lock()
+		defer mutex.Unlock()
+		if element, ok := cache[key]; ok { // added by another call to f
+			element.Value = entry{key, value}
+			order.MoveToFront(element)
+			return value
+		}
+		cache[key] = order.PushFront(entry{key, value})
+		if order.Len() > capacity {
+			oldest := order.Remove(order.Back()).(entry)
+			delete(cache, oldest.key)
+		}
+		return value
+	}
+}
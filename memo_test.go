@@ -0,0 +1,60 @@
+package ufunc
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func Test_Memo(t *testing.T) {
+	calls := 0
+	var fib func(int) int
+	fib = Memo(func(n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+	if got := fib(80); got != 23416728348467685 {
+		t.Errorf("expected 23416728348467685, got %d", got)
+	}
+	if calls != 81 {
+		t.Errorf("expected 81 calls, got %d", calls)
+	}
+	fib(50)
+	if calls != 81 {
+		t.Errorf("expected 81 calls, got %d", calls)
+	}
+}
+
+func Test_MemoLRU(t *testing.T) {
+	var calls []int
+	square := MemoLRU(func(n int) int {
+		calls = append(calls, n)
+		return n * n
+	}, 2)
+	for _, n := range []int{1, 2, 1, 3, 2, 1} {
+		if got := square(n); got != n*n {
+			t.Errorf("expected %d, got %d", n*n, got)
+		}
+	}
+	// 1 2 (1 cached) 3 evicts 2; 2 evicts 1; 1 evicts 3
+	if exp := []int{1, 2, 3, 2, 1}; !slices.Equal(exp, calls) {
+		t.Errorf("expected calls %v, got %v", exp, calls)
+	}
+	double := MemoLRU(func(n int) int { return n * 2 }, 10)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 100 {
+				if got := double(n + i); got != (n+i)*2 {
+					t.Errorf("expected %d, got %d", (n+i)*2, got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
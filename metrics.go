@@ -0,0 +1,104 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// StageMetrics records how many elements a pipeline stage has yielded and
+// for how long it has been (or was) running. It is safe to read (using
+// [StageMetrics.Snapshot]) from other goroutines while the stage runs.
+// Create one using [Instrument].
+type StageMetrics struct {
+	stage    string
+	count    atomic.Int64
+	mutex    sync.Mutex
+	started  time.Time
+	finished time.Time
+}
+
+// MetricsSnapshot holds a point-in-time copy of a [StageMetrics]; its
+// fields are exported so it can be published directly, e.g., using expvar
+// or encoding/json.
+type MetricsSnapshot struct {
+	Stage     string
+	Count     int64
+	Duration  time.Duration // since iteration started
+	PerSecond float64       // Count / Duration; 0 if not started
+	Running   bool
+}
+
+// Counted returns an iterator which yields every element yielded by seq,
+// and a counter which is incremented as each element is yielded (and which
+// may be read from other goroutines).
+// See also [Instrument]
+func Counted[E any](seq iter.Seq[E]) (iter.Seq[E], *atomic.Int64) {
+	var count atomic.Int64
+	return func(yield func(E) bool) {
+		for element := range seq {
+			count.Add(1)
+			if !yield(element) {
+				return
+			}
+		}
+	}, &count
+}
+
+// Instrument returns an iterator which yields every element yielded by seq,
+// and a [StageMetrics] for the given stage name which records the number
+// of elements yielded and the time from when iteration started to when it
+// finished. The metrics are reset each time the iterator is ranged over so
+// that they always describe the current (or most recent) iteration.
+//
+//	valid, metrics := Instrument(Filter(records, isValid), "validate")
+//	expvar.Publish("validate", expvar.Func(func() any {
+//		return metrics.Snapshot()
+//	}))
+//
+// See also [Counted]
+func Instrument[E any](seq iter.Seq[E], stage string,
+) (iter.Seq[E], *StageMetrics) {
+	metrics := &StageMetrics{stage: stage}
+	return func(yield func(E) bool) {
+		metrics.mutex.Lock()
+		metrics.count.Store(0)
+		metrics.started = time.Now()
+		metrics.finished = time.Time{}
+		metrics.mutex.Unlock()
+		defer func() {
+			metrics.mutex.Lock()
+			metrics.finished = time.Now()
+			metrics.mutex.Unlock()
+		}()
+		for element := range seq {
+			metrics.count.Add(1)
+			if !yield(element) {
+				return
+			}
+		}
+	}, metrics
+}
+
+// Snapshot returns the metrics' current values.
+func (me *StageMetrics) Snapshot() MetricsSnapshot {
+	snapshot := MetricsSnapshot{Stage: me.stage, Count: me.count.Load()}
+	me.mutex.Lock()
+	started, finished := me.started, me.finished
+	me.mutex.Unlock()
+	if started.IsZero() {
+		return snapshot
+	}
+	if finished.IsZero() {
+		snapshot.Running = true
+		finished = time.Now()
+	}
+	snapshot.Duration = finished.Sub(started)
+	if seconds := snapshot.Duration.Seconds(); seconds > 0 {
+		snapshot.PerSecond = float64(snapshot.Count) / seconds
+	}
+	return snapshot
+}
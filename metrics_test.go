@@ -0,0 +1,50 @@
+package ufunc
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_Counted(t *testing.T) {
+	seq, count := Counted(RangeX(0, 10, 1))
+	if count.Load() != 0 {
+		t.Errorf("expected 0, got %d", count.Load())
+	}
+	for x := range seq {
+		if x == 4 {
+			break
+		}
+	}
+	if count.Load() != 5 {
+		t.Errorf("expected 5, got %d", count.Load())
+	}
+}
+
+func Test_Instrument(t *testing.T) {
+	seq, metrics := Instrument(RangeX(0, 1000, 1), "range")
+	if snapshot := metrics.Snapshot(); snapshot.Stage != "range" ||
+		snapshot.Count != 0 || snapshot.Running ||
+		snapshot.Duration != 0 {
+		t.Errorf("expected unstarted snapshot, got %+v", snapshot)
+	}
+	for x := range seq {
+		if x == 10 {
+			if snapshot := metrics.Snapshot(); !snapshot.Running ||
+				snapshot.Count != 11 {
+				t.Errorf("expected running 11, got %+v", snapshot)
+			}
+		}
+	}
+	snapshot := metrics.Snapshot()
+	if snapshot.Running || snapshot.Count != 1000 ||
+		snapshot.Duration <= 0 || snapshot.PerSecond <= 0 {
+		t.Errorf("expected finished 1000, got %+v", snapshot)
+	}
+	if got := slices.Collect(Take(seq, 3)); !slices.Equal(got,
+		[]int{0, 1, 2}) {
+		t.Errorf("expected [0 1 2], got %v", got)
+	}
+	if snapshot := metrics.Snapshot(); snapshot.Count != 3 {
+		t.Errorf("expected reset count 3, got %d", snapshot.Count)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// MultiMap is a generic map in which each key may have any number of
+// values (kept in the order they were added). The zero value is an empty
+// map ready to use. Key iteration order is unspecified.
+type MultiMap[K comparable, V any] struct {
+	values map[K][]V
+	size   int
+}
+
+// NewMultiMap returns a new empty MultiMap.
+func NewMultiMap[K comparable, V any]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{values: map[K][]V{}}
+}
+
+// Add appends the given values to those held for the given key.
+func (me *MultiMap[K, V]) Add(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	if me.values == nil {
+		me.values = map[K][]V{}
+	}
+	me.values[key] = append(me.values[key], values...)
+	me.size += len(values)
+}
+
+// All returns an iterator which yields every key-value pair in the map;
+// a key with several values is yielded once per value.
+func (me *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, values := range me.values {
+			for _, value := range values {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Contains returns true if the key has at least one value.
+func (me *MultiMap[K, V]) Contains(key K) bool {
+	_, ok := me.values[key]
+	return ok
+}
+
+// Delete removes the key and all its values from the map (doing nothing if
+// the key isn't present).
+// See also [MultiMap.DeleteFunc]
+func (me *MultiMap[K, V]) Delete(key K) {
+	me.size -= len(me.values[key])
+	delete(me.values, key)
+}
+
+// DeleteFunc removes those of the key's values for which remove returns
+// true, and returns how many were removed. If no values remain the key is
+// removed too.
+// See also [MultiMap.Delete]
+func (me *MultiMap[K, V]) DeleteFunc(key K, remove func(V) bool) int {
+	values, ok := me.values[key]
+	if !ok {
+		return 0
+	}
+	values = slices.DeleteFunc(values, remove)
+	removed := len(me.values[key]) - len(values)
+	if len(values) == 0 {
+		delete(me.values, key)
+	} else {
+		me.values[key] = values
+	}
+	me.size -= removed
+	return removed
+}
+
+// Get returns a new slice of the key's values (which the caller may
+// freely modify), or an empty slice if the key isn't present.
+func (me *MultiMap[K, V]) Get(key K) []V {
+	return append([]V{}, me.values[key]...)
+}
+
+// Keys returns an iterator which yields every key once.
+func (me *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return maps.Keys(me.values)
+}
+
+// Len returns the number of key-value pairs in the map, i.e., the total
+// number of values.
+// See also [MultiMap.LenKeys]
+func (me *MultiMap[K, V]) Len() int { return me.size }
+
+// LenKeys returns the number of keys in the map.
+func (me *MultiMap[K, V]) LenKeys() int { return len(me.values) }
@@ -0,0 +1,59 @@
+package ufunc
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func Test_MultiMap(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	m.Add("odd", 1, 3)
+	m.Add("even", 2)
+	m.Add("odd", 5)
+	m.Add("none")
+	if m.Len() != 4 || m.LenKeys() != 2 {
+		t.Errorf("expected 4 2, got %d %d", m.Len(), m.LenKeys())
+	}
+	odd := m.Get("odd")
+	if exp := []int{1, 3, 5}; slices.Compare(exp, odd) != 0 {
+		t.Errorf("expected %v, got %v", exp, odd)
+	}
+	odd[0] = 99
+	if got := m.Get("odd")[0]; got != 1 {
+		t.Errorf("expected Get to return a copy, got %d", got)
+	}
+	if none := m.Get("none"); none == nil || len(none) != 0 ||
+		m.Contains("none") {
+		t.Errorf("expected empty non-nil slice, got %#v", none)
+	}
+	keys := slices.Sorted(m.Keys())
+	if exp := []string{"even", "odd"}; slices.Compare(exp, keys) != 0 {
+		t.Errorf("expected %v, got %v", exp, keys)
+	}
+	var pairs []string
+	for key, value := range m.All() {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", key, value))
+	}
+	slices.Sort(pairs)
+	if got := fmt.Sprint(pairs); got != "[even=2 odd=1 odd=3 odd=5]" {
+		t.Errorf("unexpected pairs %s", got)
+	}
+	if n := m.DeleteFunc("odd", func(v int) bool { return v > 2 }); n != 2 {
+		t.Errorf("expected 2 removed, got %d", n)
+	}
+	if n := m.DeleteFunc("even", func(int) bool { return true }); n != 1 ||
+		m.Contains("even") {
+		t.Errorf("expected even removed, got %d %v", n, m.Get("even"))
+	}
+	m.Delete("odd")
+	m.Delete("nonesuch")
+	if m.Len() != 0 || m.LenKeys() != 0 {
+		t.Errorf("expected empty, got %d %d", m.Len(), m.LenKeys())
+	}
+	var zero MultiMap[int, string]
+	zero.Add(1, "one")
+	if got := zero.Get(1); len(got) != 1 || got[0] != "one" {
+		t.Errorf("expected [one], got %v", got)
+	}
+}
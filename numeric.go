@@ -0,0 +1,655 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/mark-summerfield/unum"
+)
+
+// Float allows any floating-point type.
+type Float interface {
+	~float64 | ~float32
+}
+
+// Unsigned allows any unsigned integer type.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Interpolation specifies how [QuantileX] computes a quantile that lies
+// between two data points.
+type Interpolation uint8
+
+const (
+	// InterpolateLinear interpolates linearly between the two data points.
+	InterpolateLinear Interpolation = iota
+	// InterpolateLower uses the lower of the two data points.
+	InterpolateLower
+	// InterpolateHigher uses the higher of the two data points.
+	InterpolateHigher
+	// InterpolateNearest uses the nearer of the two data points (the even
+	// indexed one if they are equally near).
+	InterpolateNearest
+	// InterpolateMidpoint uses the mean of the two data points.
+	InterpolateMidpoint
+)
+
+// AddSlices returns a new slice whose elements are the element-wise sums
+// of a and b. AddSlices panics if a and b are different lengths.
+// See also [SubSlices]
+func AddSlices[N unum.Number](a, b []N) []N {
+	checkSameLen(len(a), len(b))
+	sums := make([]N, len(a))
+	for i, x := range a {
+		sums[i] = x + b[i]
+	}
+	return sums
+}
+
+// AllClose returns true if a and b are the same length and every pair of
+// corresponding values is close according to [unum.IsClose].
+// See also [AllCloseX] and [slices.EqualFunc]
+func AllClose(a, b []float64) bool {
+	return slices.EqualFunc(a, b, unum.IsClose)
+}
+
+// AllCloseX returns true if a and b are the same length and for every pair
+// of corresponding values x and y, |x - y| <= max(relTol * max(|x|, |y|),
+// absTol). Two NaNs are never close; two equal infinities are, but an
+// infinity is never close to any other value.
+// AllCloseX panics if either tolerance is < 0.
+// See also [AllClose]
+func AllCloseX(a, b []float64, relTol, absTol float64) bool {
+	if relTol < 0 || absTol < 0 {
+		panic("tolerances must be >= 0")
+	}
+	return slices.EqualFunc(a, b, func(x, y float64) bool {
+		if x == y {
+			return true
+		}
+		if math.IsInf(x, 0) || math.IsInf(y, 0) {
+			return false
+		}
+		return math.Abs(x-y) <= max(relTol*max(math.Abs(x), math.Abs(y)),
+			absTol)
+	})
+}
+
+// ArgMax returns the index position of the (first) largest of the values,
+// or -1 if values is empty.
+// See also [ArgMin] and [MaxBy]
+func ArgMax[N unum.Number](values []N) int {
+	return argBest(values, func(a, b N) bool { return a > b })
+}
+
+// ArgMin returns the index position of the (first) smallest of the values,
+// or -1 if values is empty.
+// See also [ArgMax] and [MinBy]
+func ArgMin[N unum.Number](values []N) int {
+	return argBest(values, func(a, b N) bool { return a < b })
+}
+
+// Bincount returns a slice of counts where counts[i] is the number of times
+// i occurs in the values, so it has max(values)+1 elements (or none if
+// values is empty). Bincount panics if any value is negative.
+// See also [Histogram]
+func Bincount(values []int) []int {
+	smallest, largest, ok := MinMax(values)
+	if !ok {
+		return []int{}
+	}
+	if smallest < 0 {
+		panic("values must be >= 0")
+	}
+	counts := make([]int, largest+1)
+	for _, x := range values {
+		counts[x]++
+	}
+	return counts
+}
+
+// Clamp replaces every value in the values slice in place that is less
+// than lo with lo and every value that is greater than hi with hi.
+// Clamp panics if lo > hi.
+// See also [Clamped]
+func Clamp[N unum.Number](values []N, lo, hi N) {
+	if lo > hi {
+		panic("lo must be <= hi")
+	}
+	for i, x := range values {
+		values[i] = min(max(x, lo), hi)
+	}
+}
+
+// Clamped returns a copy of the values slice with every value less than lo
+// replaced with lo and every value greater than hi replaced with hi; the
+// values slice itself is unchanged. Clamped panics if lo > hi.
+// See also [Clamp]
+func Clamped[N unum.Number](values []N, lo, hi N) []N {
+	clamped := slices.Clone(values)
+	Clamp(clamped, lo, hi)
+	return clamped
+}
+
+// CumFunc returns a new slice of running accumulations: the first element
+// is values[0], and each subsequent element is the result of calling the
+// accumulate function with the previous result and the corresponding value.
+// For example, CumFunc(values, max) gives running maximums.
+// See also [CumSum] and [Reduce]
+func CumFunc[E any](values []E, accumulate func(E, E) E) []E {
+	accumulated := make([]E, len(values))
+	for i, x := range values {
+		if i == 0 {
+			accumulated[i] = x
+		} else {
+			accumulated[i] = accumulate(accumulated[i-1], x)
+		}
+	}
+	return accumulated
+}
+
+// CumSum returns a new slice of running totals (prefix sums) of the
+// values.
+// See also [CumFunc] and [Diff]
+func CumSum[N unum.Number](values []N) []N {
+	return CumFunc(values, func(a, b N) N { return a + b })
+}
+
+// Diff returns a new slice of the differences between consecutive values
+// (values[1]-values[0], values[2]-values[1], …), so it is one shorter than
+// values (or empty if values has fewer than two elements). For integer
+// values, Diff undoes [CumSum] apart from the first element.
+func Diff[N unum.SignedNumber](values []N) []N {
+	if len(values) < 2 {
+		return []N{}
+	}
+	diffs := make([]N, len(values)-1)
+	for i := range diffs {
+		diffs[i] = values[i+1] - values[i]
+	}
+	return diffs
+}
+
+// Dot returns the dot (scalar) product of a and b. Dot panics if a and b
+// are different lengths.
+// See also [Norm]
+func Dot[N unum.Number](a, b []N) N {
+	checkSameLen(len(a), len(b))
+	var total N
+	for i, x := range a {
+		total += x * b[i]
+	}
+	return total
+}
+
+// EWMA returns an iterator which yields the exponentially weighted moving
+// average of the values yielded by seq: the first average is the first
+// value, and each subsequent average is alpha×value + (1-alpha)×previous.
+// A larger alpha gives more weight to recent values.
+// EWMA panics if alpha isn't in the range (0, 1].
+// See also [MovingAverage] and [SlidingReduce]
+func EWMA[F Float](seq iter.Seq[F], alpha F) iter.Seq[F] {
+	if !(alpha > 0 && alpha <= 1) {
+		panic("alpha must be in the range (0, 1]")
+	}
+	return func(yield func(F) bool) {
+		var average F
+		first := true
+		for x := range seq {
+			if first {
+				average, first = x, false
+			} else {
+				average = alpha*x + (1-alpha)*average
+			}
+			if !yield(average) {
+				return
+			}
+		}
+	}
+}
+
+// Histogram divides the range from the smallest to the largest of the
+// values into bins equal-width bins and returns how many values fall into
+// each bin, and the bins+1 bin edges. Each bin includes its lower edge;
+// the last bin also includes its upper edge. NaNs and infinities are
+// ignored. If values is empty the range is [0, 1]; if all the values are
+// equal the range is [value-0.5, value+0.5]. Histogram panics if bins is
+// <= 0.
+// See also [Bincount]
+func Histogram(values []float64, bins int) ([]int, []float64) {
+	if bins <= 0 {
+		panic("bins must be > 0")
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, x := range values {
+		if !math.IsNaN(x) && !math.IsInf(x, 0) {
+			lo, hi = min(lo, x), max(hi, x)
+		}
+	}
+	if lo > hi { // empty or all NaN or ±Inf
+		lo, hi = 0, 1
+	} else if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+	scale := 1.0
+	if math.IsInf(hi-lo, 1) { // work with halves to avoid overflow
+		scale = 0.5
+	}
+	width := (hi*scale - lo*scale) / float64(bins)
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = (lo*scale + float64(i)*width) / scale
+	}
+	edges[bins] = hi
+	counts := make([]int, bins)
+	for _, x := range values {
+		if !math.IsNaN(x) && !math.IsInf(x, 0) {
+			counts[min(int((x*scale-lo*scale)/width), bins-1)]++
+		}
+	}
+	return counts, edges
+}
+
+// Max returns the largest of the values and true, or the zero value and
+// false if values is empty.
+// See also [Min] and [MinMax]
+func Max[N unum.Number](values []N) (N, bool) {
+	_, largest, ok := MinMax(values)
+	return largest, ok
+}
+
+// Mean returns the arithmetic mean of the values, or NaN if values is
+// empty.
+func Mean[N unum.Number](values []N) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	total := 0.0
+	for _, x := range values {
+		total += float64(x)
+	}
+	return total / float64(len(values))
+}
+
+// MovingAverage returns a new slice of the means of every window of size
+// consecutive values, computed with a running total in a single pass. The
+// result is empty if size > len(values).
+// MovingAverage panics if size is <= 0.
+// See also [WindowAggregate]
+func MovingAverage(values []float64, size int) []float64 {
+	if size <= 0 {
+		panic("size must be > 0")
+	}
+	if size > len(values) {
+		return []float64{}
+	}
+	averages := make([]float64, 0, len(values)-size+1)
+	total := Sum(values[:size])
+	averages = append(averages, total/float64(size))
+	for i := size; i < len(values); i++ {
+		total += values[i] - values[i-size]
+		averages = append(averages, total/float64(size))
+	}
+	return averages
+}
+
+// Median returns the median of the values (the mean of the middle two if
+// there's an even number of values), or NaN if values is empty.
+// See also [Quantile]
+func Median[N unum.Number](values []N) float64 {
+	return Quantile(values, 0.5)
+}
+
+// MedianSeq returns the median of the values yielded by seq, or NaN if seq
+// yields nothing.
+// See also [Median]
+func MedianSeq[N unum.Number](seq iter.Seq[N]) float64 {
+	return Median(slices.Collect(seq))
+}
+
+// Min returns the smallest of the values and true, or the zero value and
+// false if values is empty.
+// See also [Max] and [MinMax]
+func Min[N unum.Number](values []N) (N, bool) {
+	smallest, _, ok := MinMax(values)
+	return smallest, ok
+}
+
+// MinMax returns the smallest and largest of the values and true, or zero
+// values and false if values is empty. Both are found in a single pass.
+// For []float64 the faster [MinMaxFloat64] is used.
+// See also [Min] and [Max]
+func MinMax[N unum.Number](values []N) (N, N, bool) {
+	if len(values) == 0 {
+		var zero N
+		return zero, zero, false
+	}
+	if floats, ok := any(values).([]float64); ok {
+		smallest, largest, _ := MinMaxFloat64(floats)
+		return any(smallest).(N), any(largest).(N), true
+	}
+	smallest, largest := values[0], values[0]
+	for _, x := range values[1:] {
+		if x < smallest {
+			smallest = x
+		} else if x > largest {
+			largest = x
+		}
+	}
+	return smallest, largest, true
+}
+
+// MinMaxFloat64 is the same as [MinMax] for []float64 but uses several
+// independent comparisons per loop iteration which is much faster for
+// large slices.
+func MinMaxFloat64(values []float64) (float64, float64, bool) {
+	if len(values) == 0 {
+		return 0, 0, false
+	}
+	lo0, lo1, lo2, lo3 := values[0], values[0], values[0], values[0]
+	hi0, hi1, hi2, hi3 := lo0, lo0, lo0, lo0
+	i := 1
+	for ; i+4 <= len(values); i += 4 {
+		a, b, c, d := values[i], values[i+1], values[i+2], values[i+3]
+		if a < lo0 {
+			lo0 = a
+		} else if a > hi0 {
+			hi0 = a
+		}
+		if b < lo1 {
+			lo1 = b
+		} else if b > hi1 {
+			hi1 = b
+		}
+		if c < lo2 {
+			lo2 = c
+		} else if c > hi2 {
+			hi2 = c
+		}
+		if d < lo3 {
+			lo3 = d
+		} else if d > hi3 {
+			hi3 = d
+		}
+	}
+	for _, x := range values[i:] {
+		if x < lo0 {
+			lo0 = x
+		} else if x > hi0 {
+			hi0 = x
+		}
+	}
+	for _, x := range [...]float64{lo1, lo2, lo3} {
+		if x < lo0 {
+			lo0 = x
+		}
+	}
+	for _, x := range [...]float64{hi1, hi2, hi3} {
+		if x > hi0 {
+			hi0 = x
+		}
+	}
+	return lo0, hi0, true
+}
+
+// Norm returns the Euclidean (L2) norm, i.e., the length, of the values
+// treated as a vector.
+// See also [Dot]
+func Norm[N unum.Number](values []N) float64 {
+	total := 0.0
+	for _, x := range values {
+		total += float64(x) * float64(x)
+	}
+	return math.Sqrt(total)
+}
+
+// Normalize rescales the values in place so that the smallest becomes 0
+// and the largest becomes 1. If all the values are equal they all become 0.
+// See also [NormalizeSum]
+func Normalize[F Float](values []F) {
+	lo, hi, ok := MinMax(values)
+	if !ok {
+		return
+	}
+	span := hi - lo
+	for i, x := range values {
+		if span == 0 {
+			values[i] = 0
+		} else {
+			values[i] = (x - lo) / span
+		}
+	}
+}
+
+// NormalizeSum rescales the values in place so that they sum to 1 (e.g.,
+// to turn scores or counts into proportions). If the values sum to 0 they
+// are left unchanged.
+// See also [Normalize]
+func NormalizeSum[F Float](values []F) {
+	total := Sum(values)
+	if total == 0 {
+		return
+	}
+	for i, x := range values {
+		values[i] = x / total
+	}
+}
+
+// Quantile returns the q-th quantile of the values using linear
+// interpolation, or NaN if values is empty. For example, Quantile(values,
+// 0.5) is the median and Quantile(values, 0.9) is the 90th percentile.
+// Quantile panics if q isn't in the range [0, 1].
+// See also [QuantileX] and [Median]
+func Quantile[N unum.Number](values []N, q float64) float64 {
+	return QuantileX(values, q, InterpolateLinear)
+}
+
+// QuantileSeq returns the q-th quantile of the values yielded by seq using
+// linear interpolation, or NaN if seq yields nothing.
+// See also [Quantile]
+func QuantileSeq[N unum.Number](seq iter.Seq[N], q float64) float64 {
+	return Quantile(slices.Collect(seq), q)
+}
+
+// QuantileX returns the q-th quantile of the values using the given
+// interpolation when the quantile lies between two data points, or NaN if
+// values is empty. The values slice itself is not modified.
+// QuantileX panics if q isn't in the range [0, 1].
+// See also [Quantile]
+func QuantileX[N unum.Number](values []N, q float64,
+	interpolation Interpolation,
+) float64 {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		panic("q must be in the range [0, 1]")
+	}
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	position := q * float64(len(sorted)-1)
+	i := int(position)
+	lower := float64(sorted[i])
+	higher := float64(sorted[min(i+1, len(sorted)-1)])
+	fraction := position - float64(i)
+	switch interpolation {
+	case InterpolateLower:
+		return lower
+	case InterpolateHigher:
+		if fraction == 0 {
+			return lower
+		}
+		return higher
+	case InterpolateNearest:
+		if math.RoundToEven(position) == float64(i) {
+			return lower
+		}
+		return higher
+	case InterpolateMidpoint:
+		if fraction == 0 {
+			return lower
+		}
+		return (lower + higher) / 2
+	default:
+		return lower + (higher-lower)*fraction
+	}
+}
+
+// ScaleSlice returns a new slice whose elements are the values each
+// multiplied by factor.
+func ScaleSlice[N unum.Number](values []N, factor N) []N {
+	scaled := make([]N, len(values))
+	for i, x := range values {
+		scaled[i] = x * factor
+	}
+	return scaled
+}
+
+// Stddev returns the population standard deviation of the values, or NaN
+// if values is empty.
+// See also [Variance]
+func Stddev[N unum.Number](values []N) float64 {
+	return math.Sqrt(Variance(values))
+}
+
+// StddevSeq returns the population standard deviation of the values
+// yielded by seq, or NaN if seq yields nothing.
+// See also [Stddev]
+func StddevSeq[N unum.Number](seq iter.Seq[N]) float64 {
+	return math.Sqrt(VarianceSeq(seq))
+}
+
+// SubSlices returns a new slice whose elements are the element-wise
+// differences a - b. SubSlices panics if a and b are different lengths.
+// See also [AddSlices]
+func SubSlices[N unum.Number](a, b []N) []N {
+	checkSameLen(len(a), len(b))
+	diffs := make([]N, len(a))
+	for i, x := range a {
+		diffs[i] = x - b[i]
+	}
+	return diffs
+}
+
+// Sum returns the sum of the values (0 if values is empty).
+// For []int64 and []float64 the faster [SumInt64] and [SumFloat64] are
+// used.
+// See also [SumKahan]
+func Sum[N unum.Number](values []N) N {
+	switch values := any(values).(type) {
+	case []int64:
+		return any(SumInt64(values)).(N)
+	case []float64:
+		return any(SumFloat64(values)).(N)
+	}
+	var total N
+	for _, x := range values {
+		total += x
+	}
+	return total
+}
+
+// SumFloat64 returns the sum of the values (0 if values is empty) using
+// several independent accumulators which is much faster for large slices.
+// (Since the additions are done in a different order the result may differ
+// very slightly from a simple left-to-right sum.)
+// See also [Sum] and [SumKahan]
+func SumFloat64(values []float64) float64 {
+	var t0, t1, t2, t3 float64
+	i := 0
+	for ; i+4 <= len(values); i += 4 {
+		t0 += values[i]
+		t1 += values[i+1]
+		t2 += values[i+2]
+		t3 += values[i+3]
+	}
+	for _, x := range values[i:] {
+		t0 += x
+	}
+	return (t0 + t1) + (t2 + t3)
+}
+
+// SumInt64 returns the sum of the values (0 if values is empty) using
+// several independent accumulators which is much faster for large slices.
+// See also [Sum]
+func SumInt64(values []int64) int64 {
+	var t0, t1, t2, t3 int64
+	i := 0
+	for ; i+4 <= len(values); i += 4 {
+		t0 += values[i]
+		t1 += values[i+1]
+		t2 += values[i+2]
+		t3 += values[i+3]
+	}
+	for _, x := range values[i:] {
+		t0 += x
+	}
+	return t0 + t1 + t2 + t3
+}
+
+// SumKahan returns the sum of the values using Kahan-Babuška (compensated)
+// summation which is much more accurate than [Sum] when adding many
+// floating-point values of differing magnitudes.
+func SumKahan[F Float](values []F) F {
+	var total, compensation F
+	for _, x := range values {
+		t := total + x
+		if math.Abs(float64(total)) >= math.Abs(float64(x)) {
+			compensation += (total - t) + x
+		} else {
+			compensation += (x - t) + total
+		}
+		total = t
+	}
+	return total + compensation
+}
+
+// Variance returns the population variance of the values, or NaN if values
+// is empty. (For the sample variance multiply by n/(n-1).)
+// See also [Stddev]
+func Variance[N unum.Number](values []N) float64 {
+	return VarianceSeq(slices.Values(values))
+}
+
+// VarianceSeq returns the population variance of the values yielded by
+// seq, or NaN if seq yields nothing. It uses Welford's single-pass
+// algorithm so doesn't need to collect the values.
+// See also [Variance]
+func VarianceSeq[N unum.Number](seq iter.Seq[N]) float64 {
+	count := 0
+	mean, m2 := 0.0, 0.0
+	for value := range seq {
+		x := float64(value)
+		count++
+		delta := x - mean
+		mean += delta / float64(count)
+		m2 += delta * (x - mean)
+	}
+	if count == 0 {
+		return math.NaN()
+	}
+	return m2 / float64(count)
+}
+
+func argBest[N unum.Number](values []N, better func(a, b N) bool) int {
+	if len(values) == 0 {
+		return -1
+	}
+	best := 0
+	for i, x := range values[1:] {
+		if better(x, values[best]) {
+			best = i + 1
+		}
+	}
+	return best
+}
+
+func checkSameLen(a, b int) {
+	if a != b {
+		panic("slices must be the same length")
+	}
+}
@@ -0,0 +1,399 @@
+package ufunc
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/mark-summerfield/unum"
+)
+
+func Test_Sum(t *testing.T) {
+	if got := Sum([]int{1, 2, 3, 4}); got != 10 {
+		t.Errorf("expected 10, got %d", got)
+	}
+	if got := Sum([]float64{}); got != 0 {
+		t.Errorf("expected 0, got %f", got)
+	}
+	reals := []float64{1e100, 1.0, -1e100}
+	if got := Sum(reals); got != 0 {
+		t.Errorf("expected naive sum to lose precision; got %g", got)
+	}
+	if got := SumKahan(reals); got != 1 {
+		t.Errorf("expected 1, got %g", got)
+	}
+	tenths := make([]float64, 0, 10)
+	for range 10 {
+		tenths = append(tenths, 0.1)
+	}
+	if got := SumKahan(tenths); got != 1 {
+		t.Errorf("expected 1, got %.17g", got)
+	}
+}
+
+func Test_Mean(t *testing.T) {
+	if got := Mean([]int{1, 2, 3, 4}); !unum.IsClose(got, 2.5) {
+		t.Errorf("expected 2.5, got %f", got)
+	}
+	if got := Mean([]float32{}); !math.IsNaN(got) {
+		t.Errorf("expected NaN, got %f", got)
+	}
+}
+
+func Test_MinMax(t *testing.T) {
+	data := []int{5, -3, 9, 0, 12, -7, 4}
+	if lo, hi, ok := MinMax(data); lo != -7 || hi != 12 || !ok {
+		t.Errorf("expected -7 12 true, got %d %d %t", lo, hi, ok)
+	}
+	if lo, ok := Min(data); lo != -7 || !ok {
+		t.Errorf("expected -7 true, got %d %t", lo, ok)
+	}
+	if hi, ok := Max(data); hi != 12 || !ok {
+		t.Errorf("expected 12 true, got %d %t", hi, ok)
+	}
+	if lo, hi, ok := MinMax([]uint8{7}); lo != 7 || hi != 7 || !ok {
+		t.Errorf("expected 7 7 true, got %d %d %t", lo, hi, ok)
+	}
+	if _, ok := Max([]float64{}); ok {
+		t.Error("expected false for empty")
+	}
+}
+
+func Test_ArgMinMax(t *testing.T) {
+	data := []float64{5, -3, 12, 0, 12, -7, -7}
+	if i := ArgMin(data); i != 5 {
+		t.Errorf("expected 5, got %d", i)
+	}
+	if i := ArgMax(data); i != 2 {
+		t.Errorf("expected 2, got %d", i)
+	}
+	if i := ArgMax([]int{}); i != -1 {
+		t.Errorf("expected -1, got %d", i)
+	}
+}
+
+func Test_CumSum(t *testing.T) {
+	data := []int{3, 1, 4, 1, 5, 9}
+	sums := CumSum(data)
+	exp := []int{3, 4, 8, 9, 14, 23}
+	if slices.Compare(sums, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, sums)
+	}
+	exp = []int{1, 4, 1, 5, 9}
+	if got := Diff(sums); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := Diff([]float64{1}); len(got) != 0 {
+		t.Errorf("expected [], got %v", got)
+	}
+	exp = []int{3, 3, 4, 4, 5, 9}
+	if got := CumFunc(data, func(a, b int) int {
+		return max(a, b)
+	}); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := CumSum([]int{}); len(got) != 0 {
+		t.Errorf("expected [], got %v", got)
+	}
+}
+
+func Test_MovingAverage(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5, 6, 7}
+	exp := []float64{2, 3, 4, 5, 6}
+	if got := MovingAverage(data, 3); !equalReals(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := MovingAverage(data, 8); len(got) != 0 {
+		t.Errorf("expected [], got %v", got)
+	}
+	exp = data
+	if got := MovingAverage(data, 1); !equalReals(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func Test_VectorMath(t *testing.T) {
+	a := []int{1, 2, 3}
+	b := []int{4, 5, 6}
+	if got := Dot(a, b); got != 32 {
+		t.Errorf("expected 32, got %d", got)
+	}
+	if got := Norm([]float64{3, 4}); !unum.IsClose(got, 5) {
+		t.Errorf("expected 5, got %f", got)
+	}
+	exp := []int{5, 7, 9}
+	if got := AddSlices(a, b); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	exp = []int{-3, -3, -3}
+	if got := SubSlices(a, b); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	exp = []int{3, 6, 9}
+	if got := ScaleSlice(a, 3); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for different lengths")
+		}
+	}()
+	Dot(a, b[:2])
+}
+
+func Test_Histogram(t *testing.T) {
+	data := []float64{0, 1, 1.5, 2, 2.5, 3, 9.9, 10, math.NaN()}
+	counts, edges := Histogram(data, 5)
+	expc := []int{3, 3, 0, 0, 2}
+	if slices.Compare(counts, expc) != 0 {
+		t.Errorf("expected %v, got %v", expc, counts)
+	}
+	expe := []float64{0, 2, 4, 6, 8, 10}
+	if !equalReals(edges, expe) {
+		t.Errorf("expected %v, got %v", expe, edges)
+	}
+	counts, edges = Histogram([]float64{4, 4}, 2)
+	expc = []int{0, 2}
+	if slices.Compare(counts, expc) != 0 {
+		t.Errorf("expected %v, got %v", expc, counts)
+	}
+	expe = []float64{3.5, 4, 4.5}
+	if !equalReals(edges, expe) {
+		t.Errorf("expected %v, got %v", expe, edges)
+	}
+	counts, _ = Histogram(nil, 3)
+	expc = []int{0, 0, 0}
+	if slices.Compare(counts, expc) != 0 {
+		t.Errorf("expected %v, got %v", expc, counts)
+	}
+	counts, edges = Histogram([]float64{1, 2, math.Inf(1), 4,
+		math.Inf(-1)}, 3)
+	expc = []int{1, 1, 1}
+	if slices.Compare(counts, expc) != 0 {
+		t.Errorf("expected %v, got %v", expc, counts)
+	}
+	expe = []float64{1, 2, 3, 4}
+	if !equalReals(edges, expe) {
+		t.Errorf("expected %v, got %v", expe, edges)
+	}
+	counts, _ = Histogram([]float64{math.Inf(1)}, 2)
+	expc = []int{0, 0}
+	if slices.Compare(counts, expc) != 0 {
+		t.Errorf("expected %v, got %v", expc, counts)
+	}
+	counts, edges = Histogram([]float64{-math.MaxFloat64, 0,
+		math.MaxFloat64}, 2)
+	expc = []int{1, 2}
+	if slices.Compare(counts, expc) != 0 {
+		t.Errorf("expected %v, got %v", expc, counts)
+	}
+	expe = []float64{-math.MaxFloat64, 0, math.MaxFloat64}
+	if !slices.Equal(edges, expe) {
+		t.Errorf("expected %v, got %v", expe, edges)
+	}
+	counts, _ = Histogram([]float64{-math.MaxFloat64, math.MaxFloat64}, 3)
+	expc = []int{1, 0, 1}
+	if slices.Compare(counts, expc) != 0 {
+		t.Errorf("expected %v, got %v", expc, counts)
+	}
+}
+
+func Test_Bincount(t *testing.T) {
+	exp := []int{1, 3, 0, 1, 0, 2}
+	if got := Bincount([]int{1, 5, 1, 0, 3, 1, 5}); slices.Compare(got,
+		exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := Bincount(nil); len(got) != 0 {
+		t.Errorf("expected [], got %v", got)
+	}
+	defer func() {
+		if r := recover(); r != "values must be >= 0" {
+			t.Errorf("expected negative values panic, got %v", r)
+		}
+	}()
+	Bincount([]int{-3, -1})
+}
+
+func Test_Quantile(t *testing.T) {
+	data := []int{7, 1, 3, 5, 9, 2, 8, 4, 6, 10}
+	if got := Median(data); !unum.IsClose(got, 5.5) {
+		t.Errorf("expected 5.5, got %f", got)
+	}
+	if got := Median([]int{3, 1, 2}); !unum.IsClose(got, 2) {
+		t.Errorf("expected 2, got %f", got)
+	}
+	if got := MedianSeq(Range(1, 6)); !unum.IsClose(got, 3) {
+		t.Errorf("expected 3, got %f", got)
+	}
+	if got := Median([]float64{}); !math.IsNaN(got) {
+		t.Errorf("expected NaN, got %f", got)
+	}
+	for _, x := range []struct {
+		q             float64
+		interpolation Interpolation
+		exp           float64
+	}{
+		{0, InterpolateLinear, 1},
+		{1, InterpolateLinear, 10},
+		{0.9, InterpolateLinear, 9.1},
+		{0.9, InterpolateLower, 9},
+		{0.9, InterpolateHigher, 10},
+		{0.9, InterpolateNearest, 9},
+		{0.95, InterpolateNearest, 10},
+		{0.9, InterpolateMidpoint, 9.5},
+		{1.0 / 9, InterpolateMidpoint, 2},
+	} {
+		if got := QuantileX(data, x.q, x.interpolation); !unum.IsClose(got,
+			x.exp) {
+			t.Errorf("q=%g interpolation=%d: expected %g, got %g", x.q,
+				x.interpolation, x.exp, got)
+		}
+	}
+	if got := QuantileSeq(slices.Values(data), 0.25); !unum.IsClose(got,
+		3.25) {
+		t.Errorf("expected 3.25, got %f", got)
+	}
+	if data[0] != 7 {
+		t.Error("expected data to be unchanged")
+	}
+}
+
+func Test_Variance(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	if got := Variance(data); !unum.IsClose(got, 4) {
+		t.Errorf("expected 4, got %f", got)
+	}
+	if got := Stddev(data); !unum.IsClose(got, 2) {
+		t.Errorf("expected 2, got %f", got)
+	}
+	if got := StddevSeq(slices.Values(data)); !unum.IsClose(got, 2) {
+		t.Errorf("expected 2, got %f", got)
+	}
+	if got := Variance([]int{}); !math.IsNaN(got) {
+		t.Errorf("expected NaN, got %f", got)
+	}
+}
+
+func Test_Clamp(t *testing.T) {
+	data := []int{-5, 0, 5, 10, 15}
+	exp := []int{0, 0, 5, 10, 10}
+	if got := Clamped(data, 0, 10); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if data[0] != -5 {
+		t.Error("expected data to be unchanged")
+	}
+	Clamp(data, 0, 10)
+	if slices.Compare(data, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, data)
+	}
+}
+
+func Test_Normalize(t *testing.T) {
+	data := []float64{2, 4, 6, 10}
+	Normalize(data)
+	exp := []float64{0, 0.25, 0.5, 1}
+	if !equalReals(data, exp) {
+		t.Errorf("expected %v, got %v", exp, data)
+	}
+	same := []float64{3, 3}
+	Normalize(same)
+	exp = []float64{0, 0}
+	if !equalReals(same, exp) {
+		t.Errorf("expected %v, got %v", exp, same)
+	}
+	data = []float64{1, 3, 4}
+	NormalizeSum(data)
+	exp = []float64{0.125, 0.375, 0.5}
+	if !equalReals(data, exp) {
+		t.Errorf("expected %v, got %v", exp, data)
+	}
+}
+
+func Test_AllClose(t *testing.T) {
+	a := []float64{0.1 + 0.2, 1.0 / 3.0, 1e10}
+	b := []float64{0.3, 0.3333333333333333, 1e10}
+	if !AllClose(a, b) {
+		t.Errorf("expected %v close to %v", a, b)
+	}
+	if AllClose(a, b[:2]) {
+		t.Error("expected false for different lengths; got true")
+	}
+	if AllClose([]float64{1, 2}, []float64{1, 2.001}) {
+		t.Error("expected false; got true")
+	}
+	if !AllCloseX([]float64{1, 2}, []float64{1, 2.001}, 0.01, 0) {
+		t.Error("expected true with relTol 0.01; got false")
+	}
+	if !AllCloseX([]float64{0}, []float64{1e-9}, 0, 1e-6) {
+		t.Error("expected true with absTol 1e-6; got false")
+	}
+	inf := math.Inf(1)
+	if !AllCloseX([]float64{inf}, []float64{inf}, 0, 0) {
+		t.Error("expected equal infinities to be close")
+	}
+	if AllCloseX([]float64{inf}, []float64{1}, 1e-9, 0) {
+		t.Error("expected infinity not to be close to 1")
+	}
+	if AllCloseX([]float64{inf}, []float64{-inf}, 1e-9, 1) {
+		t.Error("expected +Inf not to be close to -Inf")
+	}
+	if AllCloseX([]float64{math.NaN()}, []float64{math.NaN()}, 1, 1) {
+		t.Error("expected NaNs not to be close")
+	}
+}
+
+func Test_SumMinMaxFast(t *testing.T) {
+	for size := range 11 {
+		ints := make([]int64, size)
+		floats := make([]float64, size)
+		var itotal int64
+		var ftotal float64
+		for i := range size {
+			ints[i] = int64((i*37)%11 - 5)
+			floats[i] = float64(ints[i]) / 4
+			itotal += ints[i]
+			ftotal += floats[i]
+		}
+		if got := Sum(ints); got != itotal {
+			t.Errorf("size %d: expected %d, got %d", size, itotal, got)
+		}
+		if got := Sum(floats); got != ftotal {
+			t.Errorf("size %d: expected %g, got %g", size, ftotal, got)
+		}
+		lo, hi, ok := MinMax(floats)
+		if size == 0 {
+			if ok || lo != 0 || hi != 0 {
+				t.Errorf("expected 0 0 false, got %g %g %t", lo, hi, ok)
+			}
+			continue
+		}
+		if !ok || lo != slices.Min(floats) || hi != slices.Max(floats) {
+			t.Errorf("size %d: expected %g %g, got %g %g", size,
+				slices.Min(floats), slices.Max(floats), lo, hi)
+		}
+	}
+	values := []float64{3, math.NaN(), -2, 8, 1, 0}
+	if lo, hi, ok := MinMax(values); !ok || lo != -2 || hi != 8 {
+		t.Errorf("expected -2 8 true, got %g %g %t", lo, hi, ok)
+	}
+}
+
+func Test_EWMA(t *testing.T) {
+	got := slices.Collect(EWMA(slices.Values([]float64{10, 20, 20, 0}),
+		0.5))
+	if exp := []float64{10, 15, 17.5, 8.75}; !AllClose(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	got = slices.Collect(EWMA(slices.Values([]float64{3, 7}), 1))
+	if exp := []float64{3, 7}; !AllClose(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for alpha 0")
+		}
+	}()
+	EWMA(slices.Values([]float64{1}), 0)
+}
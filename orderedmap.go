@@ -0,0 +1,88 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"slices"
+)
+
+// OrderedMap is a generic map which preserves the insertion order of its
+// keys. The zero value is an empty map ready to use. Deletion is O(n).
+type OrderedMap[K comparable, V any] struct {
+	keys   []K
+	values map[K]V
+}
+
+// NewOrderedMap returns a new empty OrderedMap.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{values: map[K]V{}}
+}
+
+// All returns an iterator which yields every key-value pair in the map in
+// key insertion order.
+func (me *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range me.keys {
+			if !yield(key, me.values[key]) {
+				return
+			}
+		}
+	}
+}
+
+// Contains returns true if the key is in the map.
+func (me *OrderedMap[K, V]) Contains(key K) bool {
+	_, ok := me.values[key]
+	return ok
+}
+
+// Delete removes the key and its value from the map (doing nothing if the
+// key isn't present).
+func (me *OrderedMap[K, V]) Delete(key K) {
+	if _, ok := me.values[key]; ok {
+		delete(me.values, key)
+		if i := slices.Index(me.keys, key); i > -1 {
+			me.keys = slices.Delete(me.keys, i, i+1)
+		}
+	}
+}
+
+// Get returns the value for the given key and true, or the zero value and
+// false if the key isn't present.
+func (me *OrderedMap[K, V]) Get(key K) (V, bool) {
+	value, ok := me.values[key]
+	return value, ok
+}
+
+// Keys returns an iterator which yields every key in insertion order.
+func (me *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return slices.Values(me.keys)
+}
+
+// Len returns the number of key-value pairs in the map.
+func (me *OrderedMap[K, V]) Len() int { return len(me.keys) }
+
+// Set sets the value for the given key. A new key is added at the end; an
+// existing key keeps its position.
+func (me *OrderedMap[K, V]) Set(key K, value V) {
+	if me.values == nil {
+		me.values = map[K]V{}
+	}
+	if _, ok := me.values[key]; !ok {
+		me.keys = append(me.keys, key)
+	}
+	me.values[key] = value
+}
+
+// Values returns an iterator which yields every value in key insertion
+// order.
+func (me *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, key := range me.keys {
+			if !yield(me.values[key]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package ufunc
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_OrderedMap(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("zeta", 1)
+	m.Set("alpha", 2)
+	m.Set("mu", 3)
+	m.Set("zeta", 4)
+	keys := []string{"zeta", "alpha", "mu"}
+	if got := slices.Collect(m.Keys()); slices.Compare(got, keys) != 0 {
+		t.Errorf("expected %v, got %v", keys, got)
+	}
+	values := []int{4, 2, 3}
+	if got := slices.Collect(m.Values()); slices.Compare(got,
+		values) != 0 {
+		t.Errorf("expected %v, got %v", values, got)
+	}
+	m.Delete("alpha")
+	m.Delete("nonesuch")
+	if v, ok := m.Get("alpha"); ok || m.Contains("alpha") {
+		t.Errorf("expected alpha deleted, got %d", v)
+	}
+	if v, ok := m.Get("mu"); !ok || v != 3 {
+		t.Errorf("expected 3 true, got %d %t", v, ok)
+	}
+	var got []string
+	for k := range m.All() {
+		got = append(got, k)
+	}
+	keys = []string{"zeta", "mu"}
+	if slices.Compare(got, keys) != 0 || m.Len() != 2 {
+		t.Errorf("expected %v, got %v", keys, got)
+	}
+	var zero OrderedMap[int, bool]
+	zero.Set(1, true)
+	if !zero.Contains(1) {
+		t.Error("expected zero value to be usable")
+	}
+}
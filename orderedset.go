@@ -0,0 +1,75 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"slices"
+)
+
+// OrderedSet is a generic set of unique comparable elements which
+// preserves insertion order. The zero value is an empty set ready to use.
+// Removal is O(n).
+type OrderedSet[E comparable] struct {
+	elements []E
+	index    map[E]int
+}
+
+// NewOrderedSet returns a new OrderedSet containing the given elements in
+// order of first occurrence.
+func NewOrderedSet[E comparable](elements ...E) *OrderedSet[E] {
+	set := &OrderedSet[E]{
+		elements: make([]E, 0, len(elements)),
+		index:    make(map[E]int, len(elements)),
+	}
+	set.Add(elements...)
+	return set
+}
+
+// Add appends the given elements to the set (ignoring any that are already
+// present, whose positions are unchanged).
+func (me *OrderedSet[E]) Add(elements ...E) {
+	if me.index == nil {
+		me.index = make(map[E]int, len(elements))
+	}
+	for _, element := range elements {
+		if _, ok := me.index[element]; !ok {
+			me.index[element] = len(me.elements)
+			me.elements = append(me.elements, element)
+		}
+	}
+}
+
+// All returns an iterator which yields every element in the set in
+// insertion order.
+func (me *OrderedSet[E]) All() iter.Seq[E] {
+	return slices.Values(me.elements)
+}
+
+// Contains returns true if the element is in the set.
+func (me *OrderedSet[E]) Contains(element E) bool {
+	_, ok := me.index[element]
+	return ok
+}
+
+// Elements returns a new slice of the set's elements in insertion order.
+func (me *OrderedSet[E]) Elements() []E {
+	return slices.Clone(me.elements)
+}
+
+// Len returns the number of elements in the set.
+func (me *OrderedSet[E]) Len() int { return len(me.elements) }
+
+// Remove removes the given elements from the set (ignoring any that aren't
+// present).
+func (me *OrderedSet[E]) Remove(elements ...E) {
+	for _, element := range elements {
+		if i, ok := me.index[element]; ok {
+			delete(me.index, element)
+			me.elements = slices.Delete(me.elements, i, i+1)
+			for j := i; j < len(me.elements); j++ {
+				me.index[me.elements[j]] = j
+			}
+		}
+	}
+}
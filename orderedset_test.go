@@ -0,0 +1,35 @@
+package ufunc
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_OrderedSet(t *testing.T) {
+	set := NewOrderedSet("c", "a", "c", "b")
+	exp := []string{"c", "a", "b"}
+	if got := slices.Collect(set.All()); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	set.Add("d", "a")
+	set.Remove("c", "z")
+	exp = []string{"a", "b", "d"}
+	if got := set.Elements(); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if !set.Contains("d") || set.Contains("c") || set.Len() != 3 {
+		t.Errorf("unexpected contents %v", set.Elements())
+	}
+	set.Remove("b")
+	set.Add("c")
+	exp = []string{"a", "d", "c"}
+	if got := set.Elements(); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	var zero OrderedSet[int]
+	zero.Add(3, 1, 2)
+	if zero.Len() != 3 || !zero.Contains(1) {
+		t.Errorf("expected zero value to be usable; got %v",
+			zero.Elements())
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import "iter"
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// MakePair returns a Pair holding first and second.
+func MakePair[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{first, second}
+}
+
+// Swap returns a new Pair with the pair's values in the opposite order.
+func (me Pair[A, B]) Swap() Pair[B, A] {
+	return Pair[B, A]{me.Second, me.First}
+}
+
+// Values returns the pair's two values.
+func (me Pair[A, B]) Values() (A, B) {
+	return me.First, me.Second
+}
+
+// Triple holds three values of possibly different types.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// MakeTriple returns a Triple holding first, second, and third.
+func MakeTriple[A, B, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{first, second, third}
+}
+
+// Values returns the triple's three values.
+func (me Triple[A, B, C]) Values() (A, B, C) {
+	return me.First, me.Second, me.Third
+}
+
+// PairsToSeq2 returns an iterator which yields the values of each Pair
+// yielded by seq as a key-value pair.
+// See also [Seq2ToPairs]
+func PairsToSeq2[A, B any](seq iter.Seq[Pair[A, B]]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for pair := range seq {
+			if !yield(pair.First, pair.Second) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2ToPairs returns an iterator which yields each key-value pair yielded
+// by seq as a Pair, e.g., so that they can be collected into a slice.
+// See also [PairsToSeq2]
+func Seq2ToPairs[A, B any](seq iter.Seq2[A, B]) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		for a, b := range seq {
+			if !yield(Pair[A, B]{a, b}) {
+				return
+			}
+		}
+	}
+}
+
+// FromPairs returns a new map populated from the pairs, using each pair's
+// First as a key and its Second as that key's value; if a key occurs more
+// than once, the last pair's value is used.
+// See also [FromPairsFunc] and [Pairs]
+func FromPairs[K comparable, V any](pairs []Pair[K, V]) map[K]V {
+	m := make(map[K]V, len(pairs))
+	for _, pair := range pairs {
+		m[pair.First] = pair.Second
+	}
+	return m
+}
+
+// FromPairsFunc returns a new map populated from the pairs, using each
+// pair's First as a key and its Second as that key's value. If a key
+// occurs more than once, resolve is called with the key, the value so
+// far, and the later pair's value, and its result is used.
+// See also [FromPairs]
+func FromPairsFunc[K comparable, V any](pairs []Pair[K, V],
+	resolve func(key K, a, b V) V,
+) map[K]V {
+	m := make(map[K]V, len(pairs))
+	for _, pair := range pairs {
+		value := pair.Second
+		if old, ok := m[pair.First]; ok {
+			value = resolve(pair.First, old, value)
+		}
+		m[pair.First] = value
+	}
+	return m
+}
+
+// Pairs returns a new slice of m's key-value pairs in unspecified order.
+// For key order use, e.g., slices.Collect(Seq2ToPairs(SortedAll(m))).
+// See also [FromPairs] and [SortedAll]
+func Pairs[K comparable, V any](m map[K]V) []Pair[K, V] {
+	pairs := make([]Pair[K, V], 0, len(m))
+	for key, value := range m {
+		pairs = append(pairs, Pair[K, V]{key, value})
+	}
+	return pairs
+}
@@ -0,0 +1,60 @@
+package ufunc
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func Test_Pair(t *testing.T) {
+	pair := MakePair("x", 1)
+	swapped := pair.Swap()
+	if n, s := swapped.Values(); n != 1 || s != "x" {
+		t.Errorf("expected 1 x; got %d %s", n, s)
+	}
+	triple := MakeTriple(1, "two", 3.0)
+	if a, b, c := triple.Values(); a != 1 || b != "two" || c != 3.0 {
+		t.Errorf("expected 1 two 3; got %v", triple)
+	}
+}
+
+func Test_Seq2ToPairs(t *testing.T) {
+	pairs := slices.Collect(Seq2ToPairs(slices.All([]string{"a", "b"})))
+	exp := "[{0 a} {1 b}]"
+	if got := fmt.Sprint(pairs); got != exp {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	m := maps.Collect(PairsToSeq2(slices.Values(pairs)))
+	expm := "map[0:a 1:b]"
+	if got := fmt.Sprint(m); got != expm {
+		t.Errorf("expected %v; got %v", expm, got)
+	}
+}
+
+func Test_FromPairs(t *testing.T) {
+	pairs := []Pair[string, int]{{"a", 1}, {"b", 2}, {"a", 3}}
+	exp := "map[a:3 b:2]"
+	if got := fmt.Sprint(FromPairs(pairs)); got != exp {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	exp = "map[a:4 b:2]"
+	if got := fmt.Sprint(FromPairsFunc(pairs, func(_ string, x, y int) int {
+		return x + y
+	})); got != exp {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	m := map[string]int{"x": 1, "y": 2, "z": 3}
+	back := Pairs(m)
+	if len(back) != 3 {
+		t.Errorf("expected 3 pairs; got %v", back)
+	}
+	if got := FromPairs(back); !maps.Equal(m, got) {
+		t.Errorf("expected %v; got %v", m, got)
+	}
+	exp = "[{x 1} {y 2} {z 3}]"
+	if got := fmt.Sprint(slices.Collect(Seq2ToPairs(SortedAll(m)))); got !=
+		exp {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"runtime"
+	"sync"
+)
+
+// MapReduce splits the slice into (at most) workers contiguous spans of
+// (nearly) equal size using [Spans], calls the mapper on each span in its
+// own goroutine, and returns the mapper results combined from left to
+// right using the reducer. If workers is <= 0, [runtime.GOMAXPROCS] is
+// used. If the slice has fewer than two elements or workers is 1, the
+// mapper is called just once with the whole slice. The mapper mustn't
+// modify the slice outside the span it is given. If a mapper panics,
+// MapReduce panics with the same value after all the goroutines have
+// finished.
+//
+//	total := MapReduce(values, 0, Sum[float64], func(a, b float64) float64 {
+//		return a + b
+//	})
+func MapReduce[E, A any](slice []E, workers int, mapper func([]E) A,
+	reducer func(A, A) A,
+) A {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers == 1 || len(slice) < 2 {
+		return mapper(slice)
+	}
+	stride := (len(slice) + workers - 1) / workers
+	results := make([]A, (len(slice)+stride-1)/stride)
+	var panics []any
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	i := 0
+	for span := range Spans(slice, stride) {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					mutex.Lock()
+					panics = append(panics, r)
+					mutex.Unlock()
+				}
+			}()
+			results[i] = mapper(span)
+		}(i)
+		i++
+	}
+	wg.Wait()
+	if len(panics) > 0 {
+		panic(panics[0])
+	}
+	accumulator := results[0]
+	for _, result := range results[1:] {
+		accumulator = reducer(accumulator, result)
+	}
+	return accumulator
+}
@@ -0,0 +1,37 @@
+package ufunc
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_MapReduce(t *testing.T) {
+	values := slices.Collect(RangeX(1, 1001, 1))
+	add := func(a, b int) int { return a + b }
+	for _, workers := range []int{0, 1, 3, 7, 2000} {
+		if got := MapReduce(values, workers, Sum[int], add); got != 500500 {
+			t.Errorf("workers=%d: expected 500500, got %d", workers, got)
+		}
+	}
+	join := func(a, b []int) []int { return append(a, b...) }
+	firsts := MapReduce(values[:10], 4, func(span []int) []int {
+		return []int{span[0]}
+	}, join)
+	if exp := []int{1, 4, 7, 10}; !slices.Equal(exp, firsts) {
+		t.Errorf("expected %v, got %v", exp, firsts)
+	}
+	if got := MapReduce([]int{}, 4, Sum[int], add); got != 0 {
+		t.Errorf("expected 0, got %d", got)
+	}
+	defer func() {
+		if r := recover(); r != "bad span" {
+			t.Errorf("expected bad span panic, got %v", r)
+		}
+	}()
+	MapReduce(values, 4, func(span []int) int {
+		if span[0] > 500 {
+			panic("bad span")
+		}
+		return 0
+	}, add)
+}
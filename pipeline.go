@@ -0,0 +1,238 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+// PipelineError is the error returned by fallible pipeline functions such
+// as [TryMap] and [CollectErr]: it records which stage failed and the
+// (0-based) index of the element that caused the failure, and wraps the
+// underlying error (so [errors.Is] and [errors.As] see through it).
+type PipelineError struct {
+	Stage string // may be empty
+	Index int
+	Err   error
+}
+
+// Error returns the error's text, e.g., `stage "parse": element 7: ...`.
+func (me *PipelineError) Error() string {
+	if me.Stage == "" {
+		return fmt.Sprintf("element %d: %v", me.Index, me.Err)
+	}
+	return fmt.Sprintf("stage %q: element %d: %v", me.Stage, me.Index,
+		me.Err)
+}
+
+// Unwrap returns the underlying error.
+func (me *PipelineError) Unwrap() error { return me.Err }
+
+// CollectErr returns a new slice of the values yielded by seq up to the
+// first non-nil error and a nil error, or the values collected so far and
+// the first error. An error that isn't already a [*PipelineError] is
+// wrapped in one recording its index.
+//
+//	numbers, err := CollectErr(TryMap(lines, "parse", strconv.Atoi))
+//
+// See also [TryMap]
+func CollectErr[E any](seq iter.Seq2[E, error]) ([]E, error) {
+	values := []E{}
+	i := 0
+	for value, err := range seq {
+		if err != nil {
+			var perr *PipelineError
+			if !errors.As(err, &perr) {
+				err = &PipelineError{Index: i, Err: err}
+			}
+			return values, err
+		}
+		values = append(values, value)
+		i++
+	}
+	return values, nil
+}
+
+// Errs returns an iterator which yields only the non-nil errors yielded
+// by seq, dropping the values.
+// See also [Values] and [SplitErrors]
+func Errs[T any](seq iter.Seq2[T, error]) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		for _, err := range seq {
+			if err != nil && !yield(err) {
+				return
+			}
+		}
+	}
+}
+
+// MapWithTimeout returns an iterator which yields the result of calling
+// the mapper function on each value yielded by seq, with a nil error. Each
+// call is given a context which is cancelled after the timeout d. If the
+// mapper returns an error, or hasn't returned by the timeout, the zero
+// value and a [*PipelineError] holding the value's index (and wrapping the
+// mapper's error or [context.DeadlineExceeded]) are yielded, and iteration
+// continues with the next value. The mapper should return promptly when
+// its context is done: although MapWithTimeout doesn't wait for a timed
+// out mapper, the mapper's goroutine lives until the mapper returns.
+// See also [TryMap]
+func MapWithTimeout[S, T any](seq iter.Seq[S], d time.Duration,
+	mapper func(context.Context, S) (T, error),
+) iter.Seq2[T, error] {
+	type result struct {
+		value T
+		err   error
+	}
+	return func(yield func(T, error) bool) {
+		i := 0
+		for source := range seq {
+			ctx, cancel := context.WithTimeout(context.Background(), d)
+			results := make(chan result, 1) // so the mapper never blocks
+			go func() {
+				value, err := mapper(ctx, source)
+				results <- result{value, err}
+			}()
+			var r result
+			select {
+			case r = <-results:
+			case <-ctx.Done():
+				r.err = ctx.Err()
+			}
+			cancel()
+			if r.err != nil {
+				r.err = &PipelineError{Stage: "MapWithTimeout", Index: i,
+					Err: r.err}
+			}
+			if !yield(r.value, r.err) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// SkipTo returns an iterator which yields the elements yielded by seq
+// after skipping the first n; it is the same as [Drop] and is intended for
+// resuming from an offset saved by [WithCheckpoint].
+func SkipTo[E any](seq iter.Seq[E], n int) iter.Seq[E] {
+	return Drop(seq, n)
+}
+
+// SplitErrors returns an iterator which yields the values yielded by seq
+// that have a nil error (like [Values]), and a function which returns the
+// non-nil errors encountered so far, so that processing can continue past
+// errors and the failures be inspected at the end. The errors are cleared
+// each time the iterator is ranged over so they always belong to the
+// current (or most recent) iteration.
+//
+//	numbers, errs := SplitErrors(ParseInts(lines))
+//	total := Sum(slices.Collect(numbers))
+//	for _, err := range errs() {
+//
+// See also [Errs]
+func SplitErrors[T any](seq iter.Seq2[T, error]) (iter.Seq[T],
+	func() []error,
+) {
+	var errs []error
+	values := func(yield func(T) bool) {
+		errs = nil
+		for value, err := range seq {
+			if err != nil {
+				errs = append(errs, err)
+			} else if !yield(value) {
+				return
+			}
+		}
+	}
+	return values, func() []error { return slices.Clone(errs) }
+}
+
+// TryMap returns an iterator which yields the result of calling the
+// mapper function on each value yielded by seq, with a nil error. If the
+// mapper returns an error, TryMap yields the zero value and a
+// [*PipelineError] holding the stage name and the failing value's index,
+// and then stops.
+// See also [CollectErr]
+func TryMap[S, T any](seq iter.Seq[S], stage string,
+	mapper func(S) (T, error),
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		i := 0
+		for source := range seq {
+			target, err := mapper(source)
+			if err != nil {
+				var zero T
+				yield(zero, &PipelineError{Stage: stage, Index: i, Err: err})
+				return
+			}
+			if !yield(target, nil) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Values returns an iterator which yields only the values yielded by seq
+// that have a nil error, dropping those with errors.
+// See also [Errs], [SplitErrors], and [CollectErr]
+func Values[T any](seq iter.Seq2[T, error]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value, err := range seq {
+			if err == nil && !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// WithCheckpoint returns an iterator which yields every element yielded by
+// seq with a nil error, calling save with the number of elements consumed
+// so far after every every'th element has been consumed (i.e., after the
+// loop body has processed it), and again at the end if there are elements
+// since the last save. A long-running job can save the count (e.g., to a
+// file) and after a crash resume with [SkipTo]. If save returns an error,
+// the zero value and a [*PipelineError] wrapping the error are yielded and
+// iteration stops.
+// WithCheckpoint panics if every is <= 0.
+//
+//	done := loadOffset()
+//	for record, err := range WithCheckpoint(SkipTo(records, done), 1000,
+//		func(n int) error { return saveOffset(done + n) }) {
+//
+// See also [SkipTo]
+func WithCheckpoint[E any](seq iter.Seq[E], every int, save func(n int) error,
+) iter.Seq2[E, error] {
+	if every <= 0 {
+		panic("every must be > 0")
+	}
+	return func(yield func(E, error) bool) {
+		checkpoint := func(n int) bool {
+			if err := save(n); err != nil {
+				var zero E
+				yield(zero, &PipelineError{Stage: "checkpoint", Index: n - 1,
+					Err: err})
+				return false
+			}
+			return true
+		}
+		n := 0
+		for element := range seq {
+			if !yield(element, nil) {
+				return
+			}
+			n++
+			if n%every == 0 && !checkpoint(n) {
+				return
+			}
+		}
+		if n%every != 0 {
+			checkpoint(n)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package ufunc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func Test_TryMap(t *testing.T) {
+	lines := []string{"1", "2", "x", "4"}
+	numbers, err := CollectErr(TryMap(slices.Values(lines), "parse",
+		strconv.Atoi))
+	if exp := []int{1, 2}; !slices.Equal(exp, numbers) {
+		t.Errorf("expected %v, got %v", exp, numbers)
+	}
+	var perr *PipelineError
+	if !errors.As(err, &perr) || perr.Stage != "parse" || perr.Index != 2 {
+		t.Fatalf("expected PipelineError parse 2, got %#v", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected wrapped ErrSyntax, got %v", err)
+	}
+	exp := `stage "parse": element 2: strconv.Atoi: parsing "x": ` +
+		"invalid syntax"
+	if err.Error() != exp {
+		t.Errorf("expected %s, got %s", exp, err)
+	}
+	numbers, err = CollectErr(TryMap(slices.Values(lines[:2]), "parse",
+		strconv.Atoi))
+	if err != nil || !slices.Equal(numbers, []int{1, 2}) {
+		t.Errorf("expected [1 2] <nil>, got %v %v", numbers, err)
+	}
+}
+
+func Test_CollectErr(t *testing.T) {
+	errBad := errors.New("bad")
+	seq := func(yield func(int, error) bool) {
+		_ = yield(1, nil) && yield(2, nil) && yield(0, errBad)
+	}
+	values, err := CollectErr(seq)
+	if !slices.Equal(values, []int{1, 2}) || !errors.Is(err, errBad) {
+		t.Errorf("expected [1 2] bad, got %v %v", values, err)
+	}
+	if err.Error() != "element 2: bad" {
+		t.Errorf("expected element 2: bad, got %s", err)
+	}
+}
+
+func Test_MapWithTimeout(t *testing.T) {
+	delays := []time.Duration{0, time.Second, 0}
+	seq := MapWithTimeout(slices.Values(delays), 20*time.Millisecond,
+		func(ctx context.Context, d time.Duration) (string, error) {
+			select {
+			case <-time.After(d):
+				return d.String(), nil
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		})
+	values := []string{}
+	var errs []error
+	for value, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			values = append(values, value)
+		}
+	}
+	if exp := []string{"0s", "0s"}; !slices.Equal(exp, values) {
+		t.Errorf("expected %v, got %v", exp, values)
+	}
+	var perr *PipelineError
+	if len(errs) != 1 || !errors.Is(errs[0], context.DeadlineExceeded) ||
+		!errors.As(errs[0], &perr) || perr.Index != 1 {
+		t.Errorf("expected one deadline error at 1, got %v", errs)
+	}
+}
+
+func Test_WithCheckpoint(t *testing.T) {
+	var saved []int
+	save := func(n int) error {
+		saved = append(saved, n)
+		return nil
+	}
+	values := []int{}
+	for value, err := range WithCheckpoint(RangeX(0, 7, 1), 3, save) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, value)
+	}
+	if len(values) != 7 || !slices.Equal(saved, []int{3, 6, 7}) {
+		t.Errorf("expected 7 values [3 6 7], got %v %v", values, saved)
+	}
+	saved = saved[:0]
+	for value := range WithCheckpoint(RangeX(0, 7, 1), 2, save) {
+		if value == 4 { // "crash" while processing the 5th element
+			break
+		}
+	}
+	resumed := slices.Collect(SkipTo(RangeX(0, 7, 1), saved[len(saved)-1]))
+	if exp := []int{4, 5, 6}; !slices.Equal(exp, resumed) {
+		t.Errorf("expected %v, got %v", exp, resumed)
+	}
+	errFull := errors.New("disk full")
+	count := 0
+	var last error
+	for _, err := range WithCheckpoint(RangeX(0, 7, 1), 2,
+		func(int) error { return errFull }) {
+		if err != nil {
+			last = err
+		} else {
+			count++
+		}
+	}
+	if count != 2 || !errors.Is(last, errFull) {
+		t.Errorf("expected 2 disk full, got %d %v", count, last)
+	}
+}
+
+func Test_ValuesErrs(t *testing.T) {
+	lines := []string{"1", "x", "3", "", "5"}
+	numbers := slices.Collect(Values(ParseInts(slices.Values(lines))))
+	if exp := []int{1, 3, 5}; !slices.Equal(exp, numbers) {
+		t.Errorf("expected %v, got %v", exp, numbers)
+	}
+	errs := slices.Collect(Errs(ParseInts(slices.Values(lines))))
+	if len(errs) != 2 || !errors.Is(errs[0], strconv.ErrSyntax) {
+		t.Errorf("expected 2 syntax errors, got %v", errs)
+	}
+	values, failures := SplitErrors(ParseInts(slices.Values(lines)))
+	if total := Sum(slices.Collect(values)); total != 9 {
+		t.Errorf("expected 9, got %d", total)
+	}
+	var perr *PipelineError
+	got := failures()
+	if len(got) != 2 || !errors.As(got[1], &perr) || perr.Index != 3 {
+		t.Errorf("expected 2 errors the second at 3, got %v", got)
+	}
+	if total := Sum(slices.Collect(values)); total != 9 {
+		t.Errorf("expected 9 again, got %d", total)
+	}
+	if got = failures(); len(got) != 2 {
+		t.Errorf("expected 2 errors after ranging again, got %v", got)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"sync"
+)
+
+// BufferPool is a generic pool of reusable slices backed by a [sync.Pool],
+// for reducing allocations (and so garbage collection) in high-throughput
+// pipelines. Create a BufferPool using [NewBufferPool]. It is safe to use
+// from multiple goroutines.
+type BufferPool[T any] struct {
+	pool     sync.Pool
+	capacity int
+}
+
+// NewBufferPool returns a new BufferPool whose buffers have at least the
+// given capacity.
+// NewBufferPool panics if capacity is < 0.
+func NewBufferPool[T any](capacity int) *BufferPool[T] {
+	if capacity < 0 {
+		panic("capacity must be >= 0")
+	}
+	me := &BufferPool[T]{capacity: capacity}
+	me.pool.New = func() any {
+		buffer := make([]T, 0, me.capacity)
+		return &buffer
+	}
+	return me
+}
+
+// Get returns an empty slice with at least the pool's capacity, either
+// reused from the pool or newly allocated.
+func (me *BufferPool[T]) Get() []T {
+	return (*me.pool.Get().(*[]T))[:0]
+}
+
+// Put returns the buffer to the pool for reuse; the caller must not use it
+// afterwards. Its elements are zeroed first so that the pool doesn't keep
+// anything they refer to alive. Buffers with less than the pool's capacity
+// are discarded.
+func (me *BufferPool[T]) Put(buffer []T) {
+	if cap(buffer) < me.capacity {
+		return
+	}
+	buffer = buffer[:cap(buffer)]
+	clear(buffer)
+	buffer = buffer[:0]
+	me.pool.Put(&buffer)
+}
+
+// ChunkPooled is like [Chunk] except that each chunk's slice is obtained
+// from the pool (whose capacity should be at least size). Ownership of
+// each chunk passes to the caller who should call pool.Put when finished
+// with it (e.g., after a worker goroutine has processed it); chunks that
+// aren't put back are simply garbage collected.
+// ChunkPooled panics if size is <= 0.
+func ChunkPooled[E any](seq iter.Seq[E], size int, pool *BufferPool[E],
+) iter.Seq[[]E] {
+	if size <= 0 {
+		panic("size must be > 0")
+	}
+	return func(yield func([]E) bool) {
+		chunk := pool.Get()
+		for element := range seq {
+			chunk = append(chunk, element)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = pool.Get()
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		} else {
+			pool.Put(chunk)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package ufunc
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_BufferPool(t *testing.T) {
+	pool := NewBufferPool[int](8)
+	buffer := pool.Get()
+	if len(buffer) != 0 || cap(buffer) < 8 {
+		t.Errorf("expected len 0 cap >= 8, got %d %d", len(buffer),
+			cap(buffer))
+	}
+	buffer = append(buffer, 1, 2, 3)
+	pool.Put(buffer)
+	pool.Put(make([]int, 0, 2)) // discarded: too small
+	for range 3 {
+		if buffer := pool.Get(); len(buffer) != 0 || cap(buffer) < 8 {
+			t.Errorf("expected len 0 cap >= 8, got %d %d", len(buffer),
+				cap(buffer))
+		}
+	}
+}
+
+func Test_ChunkPooled(t *testing.T) {
+	pool := NewBufferPool[int](3)
+	chunks := []string{}
+	for chunk := range ChunkPooled(RangeX(0, 8, 1), 3, pool) {
+		chunks = append(chunks, fmt.Sprint(chunk))
+		pool.Put(chunk)
+	}
+	if got := fmt.Sprint(chunks); got != "[[0 1 2] [3 4 5] [6 7]]" {
+		t.Errorf("expected [[0 1 2] [3 4 5] [6 7]], got %s", got)
+	}
+	kept := [][]int{}
+	for chunk := range ChunkPooled(RangeX(0, 6, 1), 3, pool) {
+		kept = append(kept, chunk) // not put back so safe to keep
+	}
+	if got := fmt.Sprint(kept); got != "[[0 1 2] [3 4 5]]" {
+		t.Errorf("expected [[0 1 2] [3 4 5]], got %s", got)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// ErrInvalidWeights is returned by [WeightedChoice] and related functions
+// if the weights aren't the same length as the values, or if any weight
+// is negative, infinite, or NaN, or if they don't sum to more than 0.
+var ErrInvalidWeights = errors.New("invalid weights")
+
+// PartialShuffle randomizes the first k positions of the values slice in
+// place using rng (or the math/rand/v2 top-level functions if rng is nil),
+// so that they hold a uniformly random selection (in random order) of all
+// the values; the remaining positions hold the rest in unspecified order.
+// This is a partial Fisher-Yates shuffle taking O(k) time: to randomly
+// sample k values without replacement, use values[:k] afterwards. If k is
+// greater than len(values), the whole slice is shuffled.
+// PartialShuffle panics if k is < 0.
+// See also [Shuffle]
+func PartialShuffle[E any](rng *rand.Rand, values []E, k int) {
+	if k < 0 {
+		panic("k must be >= 0")
+	}
+	intN := rand.IntN
+	if rng != nil {
+		intN = rng.IntN
+	}
+	for i := range min(k, len(values)) {
+		j := i + intN(len(values)-i)
+		values[i], values[j] = values[j], values[i]
+	}
+}
+
+// RandomChoice returns an infinite iterator which yields values chosen at
+// random (with replacement) using rng, so it must be used with something
+// that stops, such as [Take] or [Zip]. Using an rng with a fixed seed,
+// e.g., rand.New(rand.NewPCG(1, 2)), gives a reproducible sequence; if
+// rng is nil the math/rand/v2 top-level functions are used.
+// RandomChoice panics if values is empty.
+// See also [RandomInts] and [WeightedChoices]
+func RandomChoice[E any](rng *rand.Rand, values []E) iter.Seq[E] {
+	if len(values) == 0 {
+		panic("values must not be empty")
+	}
+	return func(yield func(E) bool) {
+		for i := range RandomInts(rng, 0, len(values)) {
+			if !yield(values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// RandomFloats returns an infinite iterator which yields random float64s
+// in the half-open interval [lo, hi) using rng (or the math/rand/v2
+// top-level functions if rng is nil).
+// RandomFloats panics if hi <= lo.
+// See also [RandomChoice]
+func RandomFloats(rng *rand.Rand, lo, hi float64) iter.Seq[float64] {
+	if hi <= lo {
+		panic("hi must be > lo")
+	}
+	float := rand.Float64
+	if rng != nil {
+		float = rng.Float64
+	}
+	return func(yield func(float64) bool) {
+		for {
+			if !yield(lo + float()*(hi-lo)) {
+				return
+			}
+		}
+	}
+}
+
+// RandomInts returns an infinite iterator which yields random ints in the
+// half-open interval [lo, hi) using rng (or the math/rand/v2 top-level
+// functions if rng is nil).
+// RandomInts panics if hi <= lo.
+//
+//	rng := rand.New(rand.NewPCG(1, 2))
+//	dice := slices.Collect(Take(RandomInts(rng, 1, 7), 10))
+//
+// See also [RandomChoice]
+func RandomInts(rng *rand.Rand, lo, hi int) iter.Seq[int] {
+	if hi <= lo {
+		panic("hi must be > lo")
+	}
+	intN := rand.IntN
+	if rng != nil {
+		intN = rng.IntN
+	}
+	return func(yield func(int) bool) {
+		for {
+			if !yield(lo + intN(hi-lo)) {
+				return
+			}
+		}
+	}
+}
+
+// Shuffle randomizes the order of the values slice in place (using a
+// Fisher-Yates shuffle) using rng (or the math/rand/v2 top-level functions
+// if rng is nil).
+// See also [PartialShuffle]
+func Shuffle[E any](rng *rand.Rand, values []E) {
+	PartialShuffle(rng, values, len(values))
+}
+
+// WeightedChoice returns one of the values chosen at random using rng (or
+// the math/rand/v2 top-level functions if rng is nil) with probability
+// proportional to its weight, and a nil error; or the zero value and
+// [ErrInvalidWeights].
+// See also [WeightedChoices] and [WeightedSampleN]
+func WeightedChoice[E any](rng *rand.Rand, values []E, weights []float64,
+) (E, error) {
+	cumulative, err := cumulativeWeights(len(values), weights)
+	if err != nil {
+		var zero E
+		return zero, err
+	}
+	return values[weightedIndex(rng, cumulative)], nil
+}
+
+// WeightedChoices returns an infinite iterator which yields values chosen
+// at random (with replacement) using rng (or the math/rand/v2 top-level
+// functions if rng is nil) with probability proportional to their weights,
+// and a nil error; or a nil iterator and [ErrInvalidWeights]. Each choice
+// takes O(log n) time.
+// See also [WeightedChoice] and [RandomChoice]
+func WeightedChoices[E any](rng *rand.Rand, values []E, weights []float64,
+) (iter.Seq[E], error) {
+	cumulative, err := cumulativeWeights(len(values), weights)
+	if err != nil {
+		return nil, err
+	}
+	return func(yield func(E) bool) {
+		for {
+			if !yield(values[weightedIndex(rng, cumulative)]) {
+				return
+			}
+		}
+	}, nil
+}
+
+// WeightedSampleN returns a new slice of n of the values chosen at random
+// without replacement using rng (or the math/rand/v2 top-level functions
+// if rng is nil), where the chance of each value being chosen is
+// proportional to its weight, and a nil error; or nil and
+// [ErrInvalidWeights] (which is also returned if fewer than n values have
+// a positive weight). (The Efraimidis-Spirakis algorithm is used.)
+// WeightedSampleN panics if n is < 0.
+// See also [WeightedChoice]
+func WeightedSampleN[E any](rng *rand.Rand, values []E, weights []float64,
+	n int,
+) ([]E, error) {
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+	if _, err := cumulativeWeights(len(values), weights); err != nil {
+		return nil, err
+	}
+	float := rand.Float64
+	if rng != nil {
+		float = rng.Float64
+	}
+	type keyed struct {
+		key   float64
+		index int
+	}
+	keys := make([]keyed, 0, len(values))
+	for i, weight := range weights {
+		if weight > 0 {
+			// log(u)/w orders the same as u^(1/w) but is more accurate
+			keys = append(keys, keyed{math.Log(1-float()) / weight, i})
+		}
+	}
+	if n > len(keys) {
+		return nil, ErrInvalidWeights
+	}
+	slices.SortFunc(keys, func(a, b keyed) int {
+		return cmp.Compare(b.key, a.key)
+	})
+	sample := make([]E, n)
+	for i, k := range keys[:n] {
+		sample[i] = values[k.index]
+	}
+	return sample, nil
+}
+
+func cumulativeWeights(size int, weights []float64) ([]float64, error) {
+	if size == 0 || len(weights) != size {
+		return nil, ErrInvalidWeights
+	}
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+	for i, weight := range weights {
+		if weight < 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+			return nil, ErrInvalidWeights
+		}
+		total += weight
+		cumulative[i] = total
+	}
+	if total <= 0 || math.IsInf(total, 0) {
+		return nil, ErrInvalidWeights
+	}
+	return cumulative, nil
+}
+
+// weightedIndex returns the first index whose cumulative weight exceeds a
+// random target; so zero-weight values can never be chosen.
+func weightedIndex(rng *rand.Rand, cumulative []float64) int {
+	var target float64
+	if rng == nil {
+		target = rand.Float64()
+	} else {
+		target = rng.Float64()
+	}
+	target *= cumulative[len(cumulative)-1]
+	i, _ := slices.BinarySearchFunc(cumulative, target,
+		func(c, t float64) int {
+			if c <= t {
+				return -1
+			}
+			return 1
+		})
+	return min(i, len(cumulative)-1)
+}
@@ -0,0 +1,156 @@
+package ufunc
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func Test_RandomInts(t *testing.T) {
+	dice := slices.Collect(Take(RandomInts(rand.New(rand.NewPCG(1, 2)), 1,
+		7), 1000))
+	again := slices.Collect(Take(RandomInts(rand.New(rand.NewPCG(1, 2)), 1,
+		7), 1000))
+	if !slices.Equal(dice, again) {
+		t.Error("expected the same sequence from the same seed")
+	}
+	counts := make([]int, 7)
+	for _, x := range dice {
+		counts[x]++
+	}
+	if counts[0] != 0 || slices.Min(counts[1:]) < 100 {
+		t.Errorf("expected all of 1-6 and no 0, got %v", counts)
+	}
+	for x := range Take(RandomInts(nil, -3, -2), 5) {
+		if x != -3 {
+			t.Errorf("expected -3, got %d", x)
+		}
+	}
+}
+
+func Test_RandomFloats(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	lo, hi, _ := MinMax(slices.Collect(Take(RandomFloats(rng, -1, 1), 1000)))
+	if lo < -1 || lo > -0.9 || hi >= 1 || hi < 0.9 {
+		t.Errorf("expected values spread over [-1, 1), got %g..%g", lo, hi)
+	}
+}
+
+func Test_RandomChoice(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	values := []string{"red", "green", "blue"}
+	seen := NewSet[string]()
+	for x := range Take(RandomChoice(rng, values), 100) {
+		seen.Add(x)
+	}
+	if !seen.Equal(NewSet(values...)) {
+		t.Errorf("expected all values chosen, got %v", seen)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for empty values")
+		}
+	}()
+	RandomChoice(rng, []int{})
+}
+
+func Test_WeightedChoice(t *testing.T) {
+	rng := rand.New(rand.NewPCG(9, 10))
+	values := []string{"a", "b", "c", "d"}
+	weights := []float64{1, 0, 3, 6}
+	choices, err := WeightedChoices(rng, values, weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]int{}
+	for x := range Take(choices, 10000) {
+		counts[x]++
+	}
+	if counts["b"] != 0 || counts["a"] < 800 || counts["a"] > 1200 ||
+		counts["c"] < 2700 || counts["c"] > 3300 || counts["d"] < 5700 ||
+		counts["d"] > 6300 {
+		t.Errorf("expected about 1000 0 3000 6000, got %v", counts)
+	}
+	if x, err := WeightedChoice(rng, values, []float64{0, 0, 1, 0}); err !=
+		nil || x != "c" {
+		t.Errorf("expected c <nil>, got %s %v", x, err)
+	}
+	for _, bad := range [][]float64{{1, 2, 3}, {0, 0, 0, 0}, {1, -1, 1, 1},
+		{1, math.NaN(), 1, 1}, {1, math.Inf(1), 1, 1}} {
+		if _, err := WeightedChoice(rng, values, bad); !errors.Is(err,
+			ErrInvalidWeights) {
+			t.Errorf("%v: expected ErrInvalidWeights, got %v", bad, err)
+		}
+	}
+	if _, err := WeightedChoices(rng, []int{}, nil); !errors.Is(err,
+		ErrInvalidWeights) {
+		t.Errorf("expected ErrInvalidWeights, got %v", err)
+	}
+}
+
+func Test_WeightedSampleN(t *testing.T) {
+	rng := rand.New(rand.NewPCG(11, 12))
+	values := []string{"a", "b", "c", "d"}
+	weights := []float64{1, 0, 3, 6}
+	firsts := map[string]int{}
+	for range 2000 {
+		sample, err := WeightedSampleN(rng, values, weights, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sample) != 3 || slices.Contains(sample, "b") ||
+			len(NewSet(sample...)) != 3 {
+			t.Fatalf("expected 3 distinct non-b values, got %v", sample)
+		}
+		firsts[sample[0]]++
+	}
+	if firsts["d"] < firsts["c"] || firsts["c"] < firsts["a"] {
+		t.Errorf("expected d most often first then c then a, got %v",
+			firsts)
+	}
+	if _, err := WeightedSampleN(rng, values, weights, 4); !errors.Is(err,
+		ErrInvalidWeights) {
+		t.Errorf("expected ErrInvalidWeights, got %v", err)
+	}
+	if sample, err := WeightedSampleN(rng, values, weights, 0); err != nil ||
+		len(sample) != 0 {
+		t.Errorf("expected [] <nil>, got %v %v", sample, err)
+	}
+}
+
+func Test_Shuffle(t *testing.T) {
+	rng := rand.New(rand.NewPCG(13, 14))
+	values := slices.Collect(RangeX(0, 20, 1))
+	shuffled := slices.Clone(values)
+	Shuffle(rng, shuffled)
+	if slices.Equal(values, shuffled) {
+		t.Error("expected a different order")
+	}
+	if !ElementsMatch(values, shuffled) {
+		t.Errorf("expected the same elements, got %v", shuffled)
+	}
+	firsts := make([]int, 4)
+	for range 4000 {
+		partial := []int{0, 1, 2, 3}
+		PartialShuffle(rng, partial, 1)
+		firsts[partial[0]]++
+		if !ElementsMatch([]int{0, 1, 2, 3}, partial) {
+			t.Fatalf("expected the same elements, got %v", partial)
+		}
+	}
+	if lo, hi, _ := MinMax(firsts); lo < 850 || hi > 1150 {
+		t.Errorf("expected each first about 1000 times, got %v", firsts)
+	}
+	same := []int{1, 2, 3}
+	PartialShuffle(nil, same, 0)
+	Shuffle(nil, []int{})
+	if !slices.Equal(same, []int{1, 2, 3}) {
+		t.Errorf("expected k=0 to change nothing, got %v", same)
+	}
+	PartialShuffle(rng, same, 10)
+	if !ElementsMatch(same, []int{1, 2, 3}) {
+		t.Errorf("expected the same elements, got %v", same)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"bufio"
+	"io"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// FixedWidth returns an iterator which reads lines from r and yields each
+// one split into fields of the given widths (in characters, i.e., runes)
+// with leading and trailing whitespace trimmed from each field, and a nil
+// error. Fields beyond the end of a short line are empty strings, and any
+// characters beyond the sum of the widths are ignored. If reading fails a
+// nil slice and the error are yielded and iteration stops.
+// FixedWidth panics if any width is <= 0.
+//
+//	for fields, err := range FixedWidth(file, []int{10, 4, 8}) {
+//
+// See also [FixedWidthX]
+func FixedWidth(r io.Reader, widths []int) iter.Seq2[[]string, error] {
+	return FixedWidthX(r, widths, true)
+}
+
+// FixedWidthX is like [FixedWidth] except that fields are only trimmed if
+// trim is true.
+func FixedWidthX(r io.Reader, widths []int, trim bool,
+) iter.Seq2[[]string, error] {
+	for _, width := range widths {
+		if width <= 0 {
+			panic("widths must be > 0")
+		}
+	}
+	return func(yield func([]string, error) bool) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSuffix(scanner.Text(), "\r")
+			fields := make([]string, len(widths))
+			for i, width := range widths {
+				end := 0
+				for n := 0; n < width && end < len(line); n++ {
+					_, size := utf8.DecodeRuneInString(line[end:])
+					end += size
+				}
+				fields[i], line = line[:end], line[end:]
+				if trim {
+					fields[i] = strings.TrimSpace(fields[i])
+				}
+			}
+			if !yield(fields, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
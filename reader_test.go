@@ -0,0 +1,52 @@
+package ufunc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func Test_FixedWidth(t *testing.T) {
+	data := "Ann       042London\r\n" +
+		"Zoë       7  Paris   extra\n" +
+		"Bob\n"
+	rows := []string{}
+	for fields, err := range FixedWidth(strings.NewReader(data),
+		[]int{10, 3, 8}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, fmt.Sprintf("%q", fields))
+	}
+	exp := `["Ann" "042" "London"] ["Zoë" "7" "Paris"] ["Bob" "" ""]`
+	if got := strings.Join(rows, " "); got != exp {
+		t.Errorf("expected %s, got %s", exp, got)
+	}
+	rows = rows[:0]
+	for fields := range FixedWidthX(strings.NewReader(data), []int{4, 6},
+		false) {
+		rows = append(rows, fmt.Sprintf("%q", fields))
+		break
+	}
+	if got := rows[0]; got != `["Ann " "      "]` {
+		t.Errorf(`expected ["Ann " "      "], got %s`, got)
+	}
+	errRead := errors.New("read failed")
+	var last error
+	count := 0
+	for fields, err := range FixedWidth(io.MultiReader(
+		strings.NewReader("abc\n"), iotest.ErrReader(errRead)),
+		[]int{2}) {
+		if err != nil {
+			last = err
+		} else if fields[0] == "ab" {
+			count++
+		}
+	}
+	if count != 1 || !errors.Is(last, errRead) {
+		t.Errorf("expected 1 read failed, got %d %v", count, last)
+	}
+}
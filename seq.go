@@ -0,0 +1,438 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"runtime"
+	"slices"
+)
+
+// BottomN returns a new slice of (up to) the n smallest elements yielded
+// by seq according to the less function, smallest first. It uses a bounded
+// [Heap] so only n elements are held in memory however many seq yields.
+// See also [TopN] and [BottomNSlice]
+func BottomN[E any](seq iter.Seq[E], n int, less func(a, b E) bool) []E {
+	return TopN(seq, n, func(a, b E) bool { return less(b, a) })
+}
+
+// BottomNSlice returns a new slice of (up to) the n smallest of the values
+// according to the less function, smallest first.
+// See also [BottomN]
+func BottomNSlice[E any](values []E, n int, less func(a, b E) bool) []E {
+	return BottomN(slices.Values(values), n, less)
+}
+
+// Chunk returns an iterator which yields slices of size consecutive
+// elements from seq; the last slice may be shorter. Each slice is newly
+// allocated so may be kept.
+// Chunk panics if size is <= 0.
+// See also [ChunkPooled] and [Spans]
+func Chunk[E any](seq iter.Seq[E], size int) iter.Seq[[]E] {
+	if size <= 0 {
+		panic("size must be > 0")
+	}
+	return func(yield func([]E) bool) {
+		chunk := make([]E, 0, size)
+		for element := range seq {
+			chunk = append(chunk, element)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]E, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// DedupBy returns an iterator which yields only the first element from seq
+// for each distinct key (as returned by the key function). Since every key
+// is remembered, memory grows with the number of distinct keys.
+// See also [DedupByWindow] and [UniqueBy]
+func DedupBy[E any, K comparable](seq iter.Seq[E], key func(E) K,
+) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		seen := make(map[K]struct{})
+		for element := range seq {
+			k := key(element)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// DedupByWindow is like [DedupBy] except that it only remembers the keys
+// of the most recent window elements it has yielded, so memory is bounded
+// (which suits endless streams); an element whose key was forgotten is
+// yielded again.
+// DedupByWindow panics if window is <= 0.
+func DedupByWindow[E any, K comparable](seq iter.Seq[E], key func(E) K,
+	window int,
+) iter.Seq[E] {
+	if window <= 0 {
+		panic("window must be > 0")
+	}
+	return func(yield func(E) bool) {
+		seen := make(map[K]struct{}, window)
+		recent := make([]K, window) // ring buffer of the keys in seen
+		next := 0
+		for element := range seq {
+			k := key(element)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			if len(seen) == window {
+				delete(seen, recent[next])
+			}
+			seen[k] = struct{}{}
+			recent[next] = k
+			next = (next + 1) % window
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Drain consumes every element yielded by seq, discarding them, and
+// returns how many there were. It is useful as the terminal of a pipeline
+// whose stages work by side-effect, and for benchmarking a sequence's cost
+// (the elements are kept alive so the compiler can't optimize their
+// production away).
+// See also [Drain2]
+func Drain[E any](seq iter.Seq[E]) int {
+	count := 0
+	var last E
+	for element := range seq {
+		last = element
+		count++
+	}
+	runtime.KeepAlive(last)
+	return count
+}
+
+// Drain2 is like [Drain] but for iterators of key-value pairs.
+func Drain2[K, V any](seq iter.Seq2[K, V]) int {
+	count := 0
+	var lastKey K
+	var lastValue V
+	for key, value := range seq {
+		lastKey, lastValue = key, value
+		count++
+	}
+	runtime.KeepAlive(lastKey)
+	runtime.KeepAlive(lastValue)
+	return count
+}
+
+// Drop returns an iterator which yields the elements of seq after skipping
+// the first count.
+// See also [Take]
+func Drop[E any](seq iter.Seq[E], count int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		i := 0
+		for element := range seq {
+			if i < count {
+				i++
+			} else if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// DropUntil returns an iterator which consumes and discards the elements
+// yielded by seq until the started channel is closed (or receives), and
+// then yields the remaining elements. The channel is checked (without
+// blocking) before each element.
+// See also [TakeUntil] and [Drop]
+func DropUntil[E any](seq iter.Seq[E], started <-chan struct{},
+) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		dropping := true
+		for element := range seq {
+			if dropping {
+				select {
+				case <-started:
+					dropping = false
+				default:
+					continue
+				}
+			}
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// EqualSeq returns true if a and b yield the same number of elements and
+// corresponding elements are equal.
+// See also [EqualSeqFunc] (and for slices, [slices.Equal])
+func EqualSeq[E comparable](a, b iter.Seq[E]) bool {
+	return EqualSeqFunc(a, b, func(x, y E) bool { return x == y })
+}
+
+// EqualSeqFunc returns true if a and b yield the same number of elements
+// and eq returns true for every pair of corresponding elements. It stops
+// as soon as a difference is found.
+// See also [EqualSeq] (and for slices, [slices.EqualFunc])
+func EqualSeqFunc[E, F any](a iter.Seq[E], b iter.Seq[F],
+	eq func(E, F) bool,
+) bool {
+	next, stop := iter.Pull(b)
+	defer stop()
+	for x := range a {
+		y, ok := next()
+		if !ok || !eq(x, y) {
+			return false
+		}
+	}
+	_, ok := next()
+	return !ok
+}
+
+// Filter returns an iterator which yields only those elements of seq for
+// which the keep function returns true.
+// See also [FilterSlice]
+func Filter[E any](seq iter.Seq[E], keep func(E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for element := range seq {
+			if keep(element) && !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// FromChan returns an iterator which yields each value received from ch
+// until ch is closed.
+//
+//	for event := range Debounce(FromChan(events), time.Second) {
+func FromChan[E any](ch <-chan E) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for element := range ch {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// IfEmpty returns an iterator which yields the elements yielded by seq, or
+// if seq yields nothing, those yielded by fallback.
+//
+//	names := IfEmpty(Filter(all, isActive), slices.Values([]string{"-"}))
+func IfEmpty[E any](seq, fallback iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		empty := true
+		for element := range seq {
+			empty = false
+			if !yield(element) {
+				return
+			}
+		}
+		if empty {
+			for element := range fallback {
+				if !yield(element) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// MergeSorted accepts any number of iterators each of which yields its
+// elements in the order given by the less function, and returns a single
+// iterator which yields all their elements in that order (a k-way merge
+// using a [Heap]). Where elements are equal, those from earlier iterators
+// come first.
+// See also [MergeSortedSlices] and [Merge]
+func MergeSorted[E any](less func(a, b E) bool,
+	seqs ...iter.Seq[E],
+) iter.Seq[E] {
+	type head struct {
+		element E
+		source  int
+	}
+	return func(yield func(E) bool) {
+		pulls := make([]func() (E, bool), 0, len(seqs))
+		heap := NewHeap(func(a, b head) bool {
+			if less(a.element, b.element) {
+				return true
+			}
+			return !less(b.element, a.element) && a.source < b.source
+		})
+		for i, seq := range seqs {
+			pull, stop := iter.Pull(seq)
+			defer stop()
+			pulls = append(pulls, pull)
+			if element, ok := pull(); ok {
+				heap.Push(head{element, i})
+			}
+		}
+		for top := range heap.Drain() {
+			if !yield(top.element) {
+				return
+			}
+			if element, ok := pulls[top.source](); ok {
+				heap.Push(head{element, top.source})
+			}
+		}
+	}
+}
+
+// OnFirst returns an iterator which yields the elements yielded by seq,
+// calling f just before the first element is yielded (each time the
+// iterator is used). If seq yields nothing f isn't called.
+// See also [OnLast] and [IfEmpty]
+func OnFirst[E any](seq iter.Seq[E], f func()) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		first := true
+		for element := range seq {
+			if first {
+				first = false
+				f()
+			}
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// OnLast returns an iterator which yields the elements yielded by seq,
+// calling f when iteration ends, whether because seq is exhausted or
+// because the consumer stopped early (or panicked), e.g., to close a
+// resource opened by [OnFirst].
+func OnLast[E any](seq iter.Seq[E], f func()) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		defer f()
+		for element := range seq {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// SlidingReduce returns an iterator which yields the result of calling the
+// aggregate function on the trailing window of size consecutive elements
+// from seq, once for each element from the size'th onwards (so nothing is
+// yielded if seq yields fewer than size elements). It is the lazy
+// equivalent of [WindowAggregate] and uses O(size) memory. The window
+// slice passed to aggregate is reused so mustn't be kept.
+// SlidingReduce panics if size is <= 0.
+func SlidingReduce[E, T any](seq iter.Seq[E], size int,
+	aggregate func([]E) T,
+) iter.Seq[T] {
+	if size <= 0 {
+		panic("size must be > 0")
+	}
+	return func(yield func(T) bool) {
+		buffer := make([]E, 0, 2*size)
+		for element := range seq {
+			if len(buffer) == cap(buffer) { // keep the last size-1
+				buffer = buffer[:copy(buffer, buffer[len(buffer)-size+1:])]
+			}
+			buffer = append(buffer, element)
+			if len(buffer) >= size {
+				if !yield(aggregate(buffer[len(buffer)-size:])) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Take returns an iterator which yields (up to) the first count elements
+// of seq.
+// See also [Drop] and [TakeWhile]
+func Take[E any](seq iter.Seq[E], count int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		if count <= 0 {
+			return
+		}
+		i := 0
+		for element := range seq {
+			if !yield(element) {
+				return
+			}
+			i++
+			if i == count {
+				return
+			}
+		}
+	}
+}
+
+// TakeUntil returns an iterator which yields the elements yielded by seq
+// until the done channel is closed (or receives), e.g., when a shutdown
+// is signalled. The channel is checked (without blocking) before each
+// element.
+// See also [DropUntil] and [TakeWhile]
+func TakeUntil[E any](seq iter.Seq[E], done <-chan struct{}) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for element := range seq {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile returns an iterator which yields the elements of seq for as
+// long as the keep function returns true.
+// See also [Take]
+func TakeWhile[E any](seq iter.Seq[E], keep func(E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for element := range seq {
+			if !keep(element) || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// TopN returns a new slice of (up to) the n largest elements yielded by
+// seq according to the less function, largest first. It uses a bounded
+// [Heap] so only n elements are held in memory however many seq yields.
+// See also [BottomN] and [TopNSlice]
+func TopN[E any](seq iter.Seq[E], n int, less func(a, b E) bool) []E {
+	if n <= 0 {
+		return []E{}
+	}
+	heap := NewHeap(less) // smallest of the largest n at the top
+	for element := range seq {
+		if heap.Len() < n {
+			heap.Push(element)
+		} else if top, _ := heap.Peek(); less(top, element) {
+			heap.Pop()
+			heap.Push(element)
+		}
+	}
+	largest := slices.Collect(heap.Drain())
+	slices.Reverse(largest)
+	return largest
+}
+
+// TopNSlice returns a new slice of (up to) the n largest of the values
+// according to the less function, largest first.
+// See also [TopN]
+func TopNSlice[E any](values []E, n int, less func(a, b E) bool) []E {
+	return TopN(slices.Values(values), n, less)
+}
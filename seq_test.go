@@ -0,0 +1,253 @@
+package ufunc
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_TakeDrop(t *testing.T) {
+	got := slices.Collect(Take(RangeFrom(1, 1), 4))
+	exp := []int{1, 2, 3, 4}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	if got = slices.Collect(Take(Range(0, 5), 0)); len(got) != 0 {
+		t.Errorf("expected []; got %v", got)
+	}
+	got = slices.Collect(Drop(Range(0, 6), 4))
+	exp = []int{4, 5}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = slices.Collect(TakeWhile(RangeFrom(1, 1), func(i int) bool {
+		return i*i < 20
+	}))
+	exp = []int{1, 2, 3, 4}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+}
+
+func Test_EqualSeq(t *testing.T) {
+	if !EqualSeq(slices.Values([]int{1, 2, 3}), RangeN(1, 3, 1)) {
+		t.Error("expected true; got false")
+	}
+	if EqualSeq(slices.Values([]int{1, 2}), slices.Values([]int{1, 2, 3})) {
+		t.Error("expected false; got true")
+	}
+	if EqualSeq(slices.Values([]int{1, 2, 3}), slices.Values([]int{1, 2})) {
+		t.Error("expected false; got true")
+	}
+	if !EqualSeq(slices.Values([]string{}), slices.Values([]string(nil))) {
+		t.Error("expected true; got false")
+	}
+	words := slices.Values([]string{"one", "three", "five"})
+	if !EqualSeqFunc(words, slices.Values([]int{3, 5, 4}),
+		func(s string, n int) bool { return len(s) == n }) {
+		t.Error("expected true; got false")
+	}
+}
+
+func Test_Filter(t *testing.T) {
+	got := slices.Collect(Filter(Range(0, 10), func(i int) bool {
+		return i%3 == 0
+	}))
+	exp := []int{0, 3, 6, 9}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+}
+
+func Test_Chunk(t *testing.T) {
+	chunks := slices.Collect(Chunk(Range(1, 8), 3))
+	exp := "[[1 2 3] [4 5 6] [7]]"
+	if got := fmt.Sprint(chunks); got != exp {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	for chunk := range Chunk(Range(0, 0), 3) {
+		t.Errorf("expected nothing; got %v", chunk)
+	}
+}
+
+func Test_MergeSorted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	got := slices.Collect(MergeSorted(less, RangeX(0, 20, 5), Range(3, 6),
+		slices.Values([]int{}), RangeX(1, 30, 10)))
+	exp := []int{0, 1, 3, 4, 5, 5, 10, 11, 15, 21}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = slices.Collect(Take(MergeSorted(less, RangeFrom(0, 2),
+		RangeFrom(1, 2)), 5))
+	exp = []int{0, 1, 2, 3, 4}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+}
+
+func Test_TopN(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	got := TopN(Range(0, 1_000_000), 3, less)
+	exp := []int{999_999, 999_998, 999_997}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	data := []int{5, 1, 9, 3, 7, 9}
+	got = TopNSlice(data, 2, less)
+	exp = []int{9, 9}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = BottomNSlice(data, 3, less)
+	exp = []int{1, 3, 5}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = BottomN(slices.Values(data), 10, less)
+	exp = []int{1, 3, 5, 7, 9, 9}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	if got = TopNSlice(data, 0, less); len(got) != 0 {
+		t.Errorf("expected []; got %v", got)
+	}
+}
+
+func Test_Drain(t *testing.T) {
+	seen := 0
+	inspect := func(yield func(int) bool) {
+		for x := range RangeX(0, 100, 1) {
+			seen += x
+			if !yield(x) {
+				return
+			}
+		}
+	}
+	if n := Drain(inspect); n != 100 || seen != 4950 {
+		t.Errorf("expected 100 4950, got %d %d", n, seen)
+	}
+	if n := Drain2(slices.All([]string{"a", "b", "c"})); n != 3 {
+		t.Errorf("expected 3, got %d", n)
+	}
+	if n := Drain(slices.Values([]int(nil))); n != 0 {
+		t.Errorf("expected 0, got %d", n)
+	}
+}
+
+func Test_DedupBy(t *testing.T) {
+	words := slices.Values([]string{"apple", "Avocado", "banana", "cherry",
+		"blueberry", "apricot", "cranberry", "date"})
+	first := func(s string) byte { return s[0] | 0x20 } // lowercase
+	got := slices.Collect(DedupBy(words, first))
+	if exp := []string{"apple", "banana", "cherry", "date"}; !slices.Equal(
+		exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	events := slices.Values([]int{1, 2, 1, 3, 1, 4, 2, 2, 5, 1})
+	got2 := slices.Collect(DedupByWindow(events, Identity[int], 2))
+	// 1 2 (1 seen) 3 forgets 1; 1 forgets 2; 4 forgets 3; 2 forgets 1;
+	// (2 seen) 5 forgets 4; 1 forgets 2
+	if exp := []int{1, 2, 3, 1, 4, 2, 5, 1}; !slices.Equal(exp, got2) {
+		t.Errorf("expected %v, got %v", exp, got2)
+	}
+	got2 = slices.Collect(DedupByWindow(slices.Values([]int{1, 1, 2, 1}),
+		Identity[int], 100))
+	if exp := []int{1, 2}; !slices.Equal(exp, got2) {
+		t.Errorf("expected %v, got %v", exp, got2)
+	}
+}
+
+func Test_TakeDropUntil(t *testing.T) {
+	done := make(chan struct{})
+	got := []int{}
+	for x := range TakeUntil(RangeX(0, 10, 1), done) {
+		got = append(got, x)
+		if x == 3 {
+			close(done)
+		}
+	}
+	if exp := []int{0, 1, 2, 3}; !slices.Equal(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	started := make(chan struct{})
+	signal := func(yield func(int) bool) {
+		for x := range RangeX(0, 10, 1) {
+			if x == 6 {
+				close(started)
+			}
+			if !yield(x) {
+				return
+			}
+		}
+	}
+	got = slices.Collect(DropUntil(signal, started))
+	if exp := []int{6, 7, 8, 9}; !slices.Equal(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if n := Drain(TakeUntil(RangeX(0, 5, 1), nil)); n != 5 {
+		t.Errorf("expected a nil channel never to stop, got %d", n)
+	}
+	if n := Drain(DropUntil(RangeX(0, 5, 1), nil)); n != 0 {
+		t.Errorf("expected a nil channel to drop all, got %d", n)
+	}
+}
+
+func Test_SlidingReduce(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for size := 1; size <= 11; size++ {
+		got := slices.Collect(SlidingReduce(slices.Values(values), size,
+			Sum[int]))
+		exp := WindowAggregate(values, size, Sum[int])
+		if !slices.Equal(exp, got) {
+			t.Errorf("size %d: expected %v, got %v", size, exp, got)
+		}
+	}
+	for average := range SlidingReduce(RangeFrom(0.0, 1), 3,
+		Mean[float64]) {
+		if average != 1 {
+			t.Errorf("expected 1, got %g", average)
+		}
+		break
+	}
+}
+
+func Test_OnFirstLast(t *testing.T) {
+	var events []string
+	seq := OnLast(OnFirst(RangeX(0, 3, 1), func() {
+		events = append(events, "open")
+	}), func() { events = append(events, "close") })
+	for x := range seq {
+		events = append(events, strconv.Itoa(x))
+	}
+	if got := strings.Join(events, " "); got != "open 0 1 2 close" {
+		t.Errorf("expected open 0 1 2 close, got %s", got)
+	}
+	events = events[:0]
+	for x := range seq {
+		events = append(events, strconv.Itoa(x))
+		break
+	}
+	if got := strings.Join(events, " "); got != "open 0 close" {
+		t.Errorf("expected open 0 close, got %s", got)
+	}
+	events = events[:0]
+	Drain(OnFirst(RangeX(0, 0, 1), func() { events = append(events, "x") }))
+	if len(events) != 0 {
+		t.Errorf("expected OnFirst not called, got %v", events)
+	}
+}
+
+func Test_IfEmpty(t *testing.T) {
+	fallback := slices.Values([]int{-1})
+	got := slices.Collect(IfEmpty(RangeX(0, 3, 1), fallback))
+	if exp := []int{0, 1, 2}; !slices.Equal(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	got = slices.Collect(IfEmpty(RangeX(0, 0, 1), fallback))
+	if exp := []int{-1}; !slices.Equal(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
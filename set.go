@@ -0,0 +1,122 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"maps"
+)
+
+// Set is a generic set of unique comparable elements. Create a Set using
+// [NewSet] or [SetFromSeq] (or make(Set[E])); a nil Set may be read but not
+// added to. Iteration order is unspecified.
+type Set[E comparable] map[E]struct{}
+
+// NewSet returns a new Set containing the given elements.
+func NewSet[E comparable](elements ...E) Set[E] {
+	set := make(Set[E], len(elements))
+	set.Add(elements...)
+	return set
+}
+
+// SetFromSeq returns a new Set containing the elements yielded by seq.
+func SetFromSeq[E comparable](seq iter.Seq[E]) Set[E] {
+	set := Set[E]{}
+	for element := range seq {
+		set[element] = struct{}{}
+	}
+	return set
+}
+
+// Add adds the given elements to the set (ignoring any that are already
+// present).
+func (me Set[E]) Add(elements ...E) {
+	for _, element := range elements {
+		me[element] = struct{}{}
+	}
+}
+
+// All returns an iterator which yields every element in the set in an
+// unspecified order.
+func (me Set[E]) All() iter.Seq[E] {
+	return maps.Keys(me)
+}
+
+// Clone returns a shallow copy of the set.
+func (me Set[E]) Clone() Set[E] {
+	if me == nil {
+		return Set[E]{}
+	}
+	return maps.Clone(me)
+}
+
+// Contains returns true if the element is in the set.
+func (me Set[E]) Contains(element E) bool {
+	_, ok := me[element]
+	return ok
+}
+
+// Difference returns a new set containing the elements of this set that
+// aren't in the other set.
+func (me Set[E]) Difference(other Set[E]) Set[E] {
+	difference := make(Set[E], len(me))
+	for element := range me {
+		if !other.Contains(element) {
+			difference[element] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// Equal returns true if this set and the other set contain exactly the
+// same elements.
+func (me Set[E]) Equal(other Set[E]) bool {
+	if len(me) != len(other) {
+		return false
+	}
+	for element := range me {
+		if !other.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns a new set containing the elements that are in both
+// this set and the other set.
+func (me Set[E]) Intersect(other Set[E]) Set[E] {
+	small, large := me, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	intersection := make(Set[E], len(small))
+	for element := range small {
+		if large.Contains(element) {
+			intersection[element] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// IsEmpty returns true if the set has no elements.
+func (me Set[E]) IsEmpty() bool { return len(me) == 0 }
+
+// Len returns the number of elements in the set.
+func (me Set[E]) Len() int { return len(me) }
+
+// Remove removes the given elements from the set (ignoring any that aren't
+// present).
+func (me Set[E]) Remove(elements ...E) {
+	for _, element := range elements {
+		delete(me, element)
+	}
+}
+
+// Union returns a new set containing the elements that are in this set or
+// the other set or both.
+func (me Set[E]) Union(other Set[E]) Set[E] {
+	union := make(Set[E], max(len(me), len(other)))
+	maps.Copy(union, me)
+	maps.Copy(union, other)
+	return union
+}
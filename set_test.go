@@ -0,0 +1,50 @@
+package ufunc
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_Set(t *testing.T) {
+	set := NewSet(1, 3, 5, 3, 1)
+	if set.Len() != 3 {
+		t.Errorf("expected 3, got %d", set.Len())
+	}
+	if !set.Contains(3) || set.Contains(2) {
+		t.Errorf("unexpected contents %v", set)
+	}
+	set.Add(2, 4)
+	set.Remove(5, 99)
+	exp := []int{1, 2, 3, 4}
+	if got := slices.Sorted(set.All()); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	clone := set.Clone()
+	clone.Add(7)
+	if set.Contains(7) || !clone.Contains(7) {
+		t.Error("expected clone to be independent")
+	}
+	var empty Set[int]
+	if !empty.IsEmpty() || empty.Contains(1) || empty.Clone() == nil {
+		t.Error("expected nil set to be readable")
+	}
+}
+
+func Test_SetOperations_Set(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := SetFromSeq(Range(3, 7))
+	for _, x := range []struct {
+		name string
+		got  Set[int]
+		exp  Set[int]
+	}{
+		{"Union", a.Union(b), NewSet(1, 2, 3, 4, 5, 6)},
+		{"Intersect", a.Intersect(b), NewSet(3, 4)},
+		{"Difference", a.Difference(b), NewSet(1, 2)},
+		{"Difference reversed", b.Difference(a), NewSet(5, 6)},
+	} {
+		if !x.got.Equal(x.exp) {
+			t.Errorf("%s: expected %v, got %v", x.name, x.exp, x.got)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import "iter"
+
+// Sized is an iterator paired with a hint of how many elements it will
+// yield, so that collectors such as [CollectAll] can preallocate. Create a
+// Sized using [WithLen]. The hint need not be exact: it only affects
+// capacity.
+type Sized[E any] struct {
+	seq  iter.Seq[E]
+	size int
+}
+
+// Sized2 is like [Sized] but for an [iter.Seq2], so that collectors such
+// as [CollectMap] can preallocate. Create a Sized2 using [WithLen2].
+type Sized2[K, V any] struct {
+	seq  iter.Seq2[K, V]
+	size int
+}
+
+// CollectAll returns a new slice of all the elements yielded by source's
+// All method, preallocated using the source's [LenHint] if it has one
+// (e.g., a [Sized], [Set], or [OrderedSet]).
+//
+//	squares := CollectAll(WithLen(Map(values, square), len(values)))
+//
+// See also [CollectMap]
+func CollectAll[E any](source interface{ All() iter.Seq[E] }) []E {
+	size, _ := LenHint(source)
+	values := make([]E, 0, size)
+	for element := range source.All() {
+		values = append(values, element)
+	}
+	return values
+}
+
+// CollectMap returns a new map of all the key-value pairs yielded by
+// source's All method (with later values replacing earlier ones for the
+// same key), preallocated using the source's [LenHint] if it has one
+// (e.g., a [Sized2] or [OrderedMap]).
+// See also [CollectAll]
+func CollectMap[K comparable, V any](source interface {
+	All() iter.Seq2[K, V]
+},
+) map[K]V {
+	size, _ := LenHint(source)
+	m := make(map[K]V, size)
+	for key, value := range source.All() {
+		m[key] = value
+	}
+	return m
+}
+
+// LenHint returns the length of x and true if x has a Len() int method
+// (e.g., a [Sized], [Sized2], [Set], [OrderedMap], or [Heap]), or 0 and
+// false otherwise. Negative lengths are treated as 0.
+// See also [CollectAll] and [CollectMap]
+func LenHint(x any) (int, bool) {
+	if sized, ok := x.(interface{ Len() int }); ok {
+		return max(0, sized.Len()), true
+	}
+	return 0, false
+}
+
+// WithLen returns a [Sized] which yields the elements yielded by seq and
+// whose length hint is size (treated as 0 if negative).
+//
+//	squares := WithLen(Map(values, square), len(values)).Collect()
+//
+// See also [WithLen2]
+func WithLen[E any](seq iter.Seq[E], size int) Sized[E] {
+	return Sized[E]{seq, max(0, size)}
+}
+
+// WithLen2 returns a [Sized2] which yields the pairs yielded by seq and
+// whose length hint is size (treated as 0 if negative).
+// See also [WithLen]
+func WithLen2[K, V any](seq iter.Seq2[K, V], size int) Sized2[K, V] {
+	return Sized2[K, V]{seq, max(0, size)}
+}
+
+// All returns the sized iterator as a plain iterator.
+func (me Sized[E]) All() iter.Seq[E] { return me.seq }
+
+// Collect returns a new slice of all the elements, preallocated using the
+// length hint; it is the same as [CollectAll].
+func (me Sized[E]) Collect() []E { return CollectAll(me) }
+
+// Len returns the length hint.
+func (me Sized[E]) Len() int { return me.size }
+
+// All returns the sized iterator as a plain iterator.
+func (me Sized2[K, V]) All() iter.Seq2[K, V] { return me.seq }
+
+// Len returns the length hint.
+func (me Sized2[K, V]) Len() int { return me.size }
@@ -0,0 +1,81 @@
+package ufunc
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_Sized(t *testing.T) {
+	values := []int{1, 2, 3, 4}
+	sized := WithLen(Map(values, func(x int) (int, bool) {
+		return x * x, true
+	}), len(values))
+	squares := sized.Collect()
+	if exp := []int{1, 4, 9, 16}; !slices.Equal(exp, squares) {
+		t.Errorf("expected %v, got %v", exp, squares)
+	}
+	if cap(squares) != 4 {
+		t.Errorf("expected capacity 4, got %d", cap(squares))
+	}
+	if n, ok := LenHint(sized); !ok || n != 4 {
+		t.Errorf("expected 4 true, got %d %t", n, ok)
+	}
+	if n, ok := LenHint(NewSet(1, 2, 3)); !ok || n != 3 {
+		t.Errorf("expected 3 true, got %d %t", n, ok)
+	}
+	if n, ok := LenHint(slices.Values(values)); ok || n != 0 {
+		t.Errorf("expected 0 false, got %d %t", n, ok)
+	}
+	short := WithLen(slices.Values(values), 2).Collect()
+	if !slices.Equal(values, short) {
+		t.Errorf("expected %v, got %v", values, short)
+	}
+	negative := WithLen(slices.Values(values), -1)
+	if got := slices.Collect(negative.All()); !slices.Equal(values, got) ||
+		negative.Len() != 0 {
+		t.Errorf("expected %v, got %v", values, got)
+	}
+}
+
+func Test_CollectAll(t *testing.T) {
+	set := NewSet(5, 3, 1)
+	got := CollectAll(set)
+	if cap(got) != 3 {
+		t.Errorf("expected capacity 3, got %d", cap(got))
+	}
+	if slices.Sort(got); !slices.Equal([]int{1, 3, 5}, got) {
+		t.Errorf("expected [1 3 5], got %v", got)
+	}
+	got = CollectAll(From([]int{7, 8}))
+	if !slices.Equal([]int{7, 8}, got) {
+		t.Errorf("expected [7 8], got %v", got)
+	}
+	if got = CollectAll(WithLen(slices.Values([]int{}), 9)); len(got) != 0 ||
+		cap(got) != 9 {
+		t.Errorf("expected [] with capacity 9, got %v %d", got, cap(got))
+	}
+}
+
+func Test_CollectMap(t *testing.T) {
+	ordered := NewOrderedMap[string, int]()
+	ordered.Set("a", 1)
+	ordered.Set("b", 2)
+	m := CollectMap(ordered)
+	if len(m) != 2 || m["a"] != 1 || m["b"] != 2 {
+		t.Errorf("expected map[a:1 b:2], got %v", m)
+	}
+	sized := WithLen2(slices.All([]string{"x", "y", "x"}), 3)
+	if n, ok := LenHint(sized); !ok || n != 3 {
+		t.Errorf("expected 3 true, got %d %t", n, ok)
+	}
+	inverted := CollectMap(WithLen2(func(yield func(string, int) bool) {
+		for i, s := range sized.All() {
+			if !yield(s, i) {
+				return
+			}
+		}
+	}, sized.Len()))
+	if len(inverted) != 2 || inverted["x"] != 2 || inverted["y"] != 1 {
+		t.Errorf("expected map[x:2 y:1], got %v", inverted)
+	}
+}
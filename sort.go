@@ -0,0 +1,151 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// SortUnique returns an iterator which yields the elements of seq sorted
+// according to the less function and without duplicates (elements where
+// neither is less than the other). It sorts chunks of chunkSize elements
+// and k-way merges them (see [MergeSorted]), so duplicates are removed as
+// early as possible. All the (unique) elements are held in memory; for
+// streams too large for that use [SortUniqueSpill].
+// SortUnique panics if chunkSize is <= 0.
+func SortUnique[E any](seq iter.Seq[E], less func(a, b E) bool,
+	chunkSize int,
+) iter.Seq[E] {
+	chunks := Chunk(seq, chunkSize) // panics now if chunkSize is invalid
+	return func(yield func(E) bool) {
+		runs := []iter.Seq[E]{}
+		for chunk := range chunks {
+			runs = append(runs, slices.Values(sortUniqueChunk(chunk, less)))
+		}
+		for element := range uniqueSorted(MergeSorted(less, runs...),
+			less) {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// SortUniqueSpill is like [SortUnique] except that each sorted chunk is
+// written to a temporary file in dir (or the default temporary directory
+// if dir is "") using [encoding/gob], so that at most chunkSize elements
+// are held in memory while sorting. The element type must therefore be
+// gob-encodable. The temporary files are removed when the iteration ends.
+// If an error occurs it is yielded (with the zero value) and the iteration
+// stops.
+// SortUniqueSpill panics if chunkSize is <= 0.
+func SortUniqueSpill[E any](seq iter.Seq[E], less func(a, b E) bool,
+	chunkSize int, dir string,
+) iter.Seq2[E, error] {
+	chunks := Chunk(seq, chunkSize) // panics now if chunkSize is invalid
+	return func(yield func(E, error) bool) {
+		var zero E
+		var err error
+		runs := []iter.Seq[E]{}
+		for chunk := range chunks {
+			var filename string
+			filename, err = spillChunk(sortUniqueChunk(chunk, less), dir)
+			if filename != "" {
+				defer os.Remove(filename)
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			runs = append(runs, unspillChunk[E](filename, &err))
+		}
+		for element := range uniqueSorted(MergeSorted(less, runs...),
+			less) {
+			if err != nil {
+				break
+			}
+			if !yield(element, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+func sortUniqueChunk[E any](chunk []E, less func(a, b E) bool) []E {
+	slices.SortFunc(chunk, func(a, b E) int {
+		if less(a, b) {
+			return -1
+		}
+		if less(b, a) {
+			return 1
+		}
+		return 0
+	})
+	return slices.CompactFunc(chunk, func(a, b E) bool {
+		return !less(a, b) && !less(b, a)
+	})
+}
+
+func uniqueSorted[E any](seq iter.Seq[E], less func(a, b E) bool,
+) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		var previous E
+		first := true
+		for element := range seq {
+			if first || less(previous, element) {
+				if !yield(element) {
+					return
+				}
+				previous, first = element, false
+			}
+		}
+	}
+}
+
+func spillChunk[E any](chunk []E, dir string) (string, error) {
+	file, err := os.CreateTemp(dir, "ufunc-sort-*.gob")
+	if err != nil {
+		return "", err
+	}
+	encoder := gob.NewEncoder(file)
+	for _, element := range chunk {
+		if err = encoder.Encode(element); err != nil {
+			break
+		}
+	}
+	return file.Name(), errors.Join(err, file.Close())
+}
+
+// unspillChunk returns an iterator which decodes the elements in filename,
+// setting *err and stopping if an error occurs.
+func unspillChunk[E any](filename string, err *error) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		file, e := os.Open(filename)
+		if e != nil {
+			*err = e
+			return
+		}
+		defer file.Close()
+		decoder := gob.NewDecoder(file)
+		for {
+			var element E
+			if e := decoder.Decode(&element); e != nil {
+				if e != io.EOF {
+					*err = e
+				}
+				return
+			}
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package ufunc
+
+import (
+	"math/rand/v2"
+	"os"
+	"slices"
+	"testing"
+)
+
+func Test_SortUnique(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	data := GenerateN(1000, func(int) int { return rng.IntN(200) })
+	exp := slices.Compact(slices.Sorted(slices.Values(data)))
+	less := func(a, b int) bool { return a < b }
+	got := slices.Collect(SortUnique(slices.Values(data), less, 64))
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = slices.Collect(Take(SortUnique(slices.Values([]int{3, 1, 3, 2}),
+		less, 2), 2))
+	if slices.Compare([]int{1, 2}, got) != 0 {
+		t.Errorf("expected [1 2]; got %v", got)
+	}
+	defer func() {
+		if r := recover(); r != "size must be > 0" {
+			t.Errorf("expected size panic, got %v", r)
+		}
+	}()
+	_ = SortUnique(slices.Values(data), less, 0) // must panic unranged
+}
+
+func Test_SortUniqueSpill(t *testing.T) {
+	dir := t.TempDir()
+	rng := rand.New(rand.NewPCG(3, 4))
+	data := GenerateN(1000, func(int) string {
+		return string(rune('a'+rng.IntN(26))) + string(rune('a'+rng.IntN(26)))
+	})
+	exp := slices.Compact(slices.Sorted(slices.Values(data)))
+	less := func(a, b string) bool { return a < b }
+	var got []string
+	for s, err := range SortUniqueSpill(slices.Values(data), less, 100,
+		dir) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, s)
+	}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected temporary files removed; got %d", len(entries))
+	}
+	for _, err := range SortUniqueSpill(slices.Values(data), less, 100,
+		dir+"/nonesuch") {
+		if err == nil {
+			t.Error("expected error for missing directory")
+		}
+	}
+	defer func() {
+		if r := recover(); r != "size must be > 0" {
+			t.Errorf("expected size panic, got %v", r)
+		}
+	}()
+	_ = SortUniqueSpill(slices.Values(data), less, -1, dir) // must panic
+}
@@ -0,0 +1,88 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"slices"
+)
+
+// Stream is an iterator (so can be used directly in a range statement)
+// with methods for chaining pipeline stages so that they read in order
+// rather than inside-out. For example:
+//
+//	evens := From(data).Filter(isEven).Map(square).Take(5).Collect()
+//
+// Go methods can't introduce new type parameters, so stages that change
+// the element type must be written as functions whose results are
+// rewrapped with [FromSeq].
+type Stream[E any] iter.Seq[E]
+
+// From returns a Stream which yields the given values.
+func From[E any](values []E) Stream[E] {
+	return Stream[E](slices.Values(values))
+}
+
+// FromSeq returns a Stream which yields the elements yielded by seq.
+func FromSeq[E any](seq iter.Seq[E]) Stream[E] {
+	return Stream[E](seq)
+}
+
+// All returns the stream as a plain iterator.
+func (me Stream[E]) All() iter.Seq[E] {
+	return iter.Seq[E](me)
+}
+
+// Chunk returns an iterator which yields slices of size consecutive
+// elements from the stream; see [Chunk].
+func (me Stream[E]) Chunk(size int) iter.Seq[[]E] {
+	return Chunk(me.All(), size)
+}
+
+// Collect returns a new slice of all the stream's elements.
+func (me Stream[E]) Collect() []E {
+	return slices.Collect(me.All())
+}
+
+// Drop returns a stream that skips the first count elements; see [Drop].
+func (me Stream[E]) Drop(count int) Stream[E] {
+	return Stream[E](Drop(me.All(), count))
+}
+
+// Filter returns a stream of only those elements for which the keep
+// function returns true; see [Filter].
+func (me Stream[E]) Filter(keep func(E) bool) Stream[E] {
+	return Stream[E](Filter(me.All(), keep))
+}
+
+// Map returns a stream of every element transformed by the mapper
+// function.
+func (me Stream[E]) Map(mapper func(E) E) Stream[E] {
+	return func(yield func(E) bool) {
+		for element := range me {
+			if !yield(mapper(element)) {
+				return
+			}
+		}
+	}
+}
+
+// Reduce returns the accumulated elements based on the reduce function and
+// the initial accumulator value; see [Reduce].
+func (me Stream[E]) Reduce(reduce func(E, E) E, accumulator E) E {
+	for element := range me {
+		accumulator = reduce(element, accumulator)
+	}
+	return accumulator
+}
+
+// Take returns a stream of (up to) the first count elements; see [Take].
+func (me Stream[E]) Take(count int) Stream[E] {
+	return Stream[E](Take(me.All(), count))
+}
+
+// TakeWhile returns a stream of the elements for as long as the keep
+// function returns true; see [TakeWhile].
+func (me Stream[E]) TakeWhile(keep func(E) bool) Stream[E] {
+	return Stream[E](TakeWhile(me.All(), keep))
+}
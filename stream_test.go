@@ -0,0 +1,38 @@
+package ufunc
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func Test_Stream(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	got := From(data).
+		Filter(func(i int) bool { return i%2 == 0 }).
+		Map(func(i int) int { return i * i }).
+		Drop(1).
+		Take(3).
+		Collect()
+	exp := []int{16, 36, 64}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	total := FromSeq(RangeFrom(1, 1)).
+		TakeWhile(func(i int) bool { return i <= 100 }).
+		Reduce(func(i, total int) int { return total + i }, 0)
+	if total != 5050 {
+		t.Errorf("expected 5050; got %d", total)
+	}
+	exps := "[[1 2 3 4 5] [6 7 8 9 10] [11 12]]"
+	if s := fmt.Sprint(slices.Collect(From(data).Chunk(5))); s != exps {
+		t.Errorf("expected %v; got %v", exps, s)
+	}
+	count := 0
+	for range From(data).Take(2) {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("expected 2; got %d", count)
+	}
+}
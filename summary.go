@@ -0,0 +1,91 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"math"
+
+	"github.com/mark-summerfield/unum"
+)
+
+// Summary accumulates the count, minimum, maximum, mean, and population
+// variance of the values yielded by the iterator returned by [Summarize],
+// as they are consumed. Its methods may be called at any time and reflect
+// the values seen so far. A Summary isn't safe for concurrent use.
+type Summary[N unum.Number] struct {
+	count    int
+	min, max N
+	mean, m2 float64
+}
+
+// Summarize returns a [Summary] and an iterator which yields every value
+// yielded by seq, adding each to the summary as it passes through. So
+// statistics are gathered as a side-effect of consuming the values
+// downstream, without a second pass or buffering. (Welford's algorithm is
+// used for the mean and variance.)
+//
+//	summary, seq := Summarize(readings)
+//	total := Drain(Filter(seq, store))
+//	fmt.Println(total, summary.Mean(), summary.Stddev())
+//
+// See also [MinMax], [Mean], and [VarianceSeq]
+func Summarize[N unum.Number](seq iter.Seq[N]) (*Summary[N], iter.Seq[N]) {
+	summary := &Summary[N]{}
+	return summary, func(yield func(N) bool) {
+		for value := range seq {
+			summary.add(value)
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Count returns how many values have been seen.
+func (me *Summary[N]) Count() int { return me.count }
+
+// Max returns the largest value seen and true, or the zero value and false
+// if no values have been seen.
+func (me *Summary[N]) Max() (N, bool) { return me.max, me.count > 0 }
+
+// Mean returns the arithmetic mean of the values seen, or NaN if none have
+// been seen.
+func (me *Summary[N]) Mean() float64 {
+	if me.count == 0 {
+		return math.NaN()
+	}
+	return me.mean
+}
+
+// Min returns the smallest value seen and true, or the zero value and
+// false if no values have been seen.
+func (me *Summary[N]) Min() (N, bool) { return me.min, me.count > 0 }
+
+// Stddev returns the population standard deviation of the values seen, or
+// NaN if none have been seen.
+func (me *Summary[N]) Stddev() float64 { return math.Sqrt(me.Variance()) }
+
+// Variance returns the population variance of the values seen, or NaN if
+// none have been seen.
+func (me *Summary[N]) Variance() float64 {
+	if me.count == 0 {
+		return math.NaN()
+	}
+	return me.m2 / float64(me.count)
+}
+
+func (me *Summary[N]) add(value N) {
+	if me.count == 0 {
+		me.min, me.max = value, value
+	} else if value < me.min {
+		me.min = value
+	} else if value > me.max {
+		me.max = value
+	}
+	me.count++
+	x := float64(value)
+	delta := x - me.mean
+	me.mean += delta / float64(me.count)
+	me.m2 += delta * (x - me.mean)
+}
@@ -0,0 +1,47 @@
+package ufunc
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/mark-summerfield/unum"
+)
+
+func Test_Summarize(t *testing.T) {
+	data := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	summary, seq := Summarize(slices.Values(data))
+	if summary.Count() != 0 || !math.IsNaN(summary.Mean()) {
+		t.Errorf("expected empty summary, got %d %g", summary.Count(),
+			summary.Mean())
+	}
+	if _, ok := summary.Min(); ok {
+		t.Error("expected no min")
+	}
+	got := slices.Collect(seq)
+	if !slices.Equal(data, got) {
+		t.Errorf("expected %v, got %v", data, got)
+	}
+	lo, lok := summary.Min()
+	hi, hok := summary.Max()
+	if summary.Count() != 8 || lo != 2 || hi != 9 || !lok || !hok {
+		t.Errorf("expected 8 2 9, got %d %d %d", summary.Count(), lo, hi)
+	}
+	if !unum.IsClose(summary.Mean(), 5) ||
+		!unum.IsClose(summary.Variance(), Variance(data)) ||
+		!unum.IsClose(summary.Stddev(), 2) {
+		t.Errorf("expected 5 4 2, got %g %g %g", summary.Mean(),
+			summary.Variance(), summary.Stddev())
+	}
+	partial, reals := Summarize(slices.Values([]float64{3, -1, 8}))
+	for x := range reals {
+		if x < 0 {
+			break
+		}
+	}
+	if lo, _ := partial.Min(); partial.Count() != 2 || lo != -1 ||
+		partial.Mean() != 1 {
+		t.Errorf("expected 2 -1 1, got %d %g %g", partial.Count(), lo,
+			partial.Mean())
+	}
+}
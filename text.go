@@ -0,0 +1,253 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/mark-summerfield/unum"
+)
+
+// TableOptions holds the options for [WriteTable]. The zero value gives
+// left-aligned columns separated by two spaces.
+type TableOptions struct {
+	// MinWidth is the minimum width of every column (excluding padding).
+	MinWidth int
+	// Padding is the number of spaces between columns; 0 means 2.
+	Padding int
+	// AlignRight means right-align the cells (e.g., for numbers); the
+	// padding then precedes every cell, including the first.
+	AlignRight bool
+}
+
+// Format returns an iterator which yields the result of calling the format
+// function on each element yielded by seq.
+//
+//	for line := range Format(records, Record.String) {
+//
+// See also [JoinSeqFunc]
+func Format[E any](seq iter.Seq[E], format func(E) string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for element := range seq {
+			if !yield(format(element)) {
+				return
+			}
+		}
+	}
+}
+
+// Ftoa returns an iterator which yields each number yielded by seq as a
+// string formatted using [strconv.FormatFloat] with the given format
+// (e.g., 'f', 'g') and precision (-1 means the fewest digits needed to
+// represent the value exactly).
+// See also [Itoa] and [ParseFloats]
+func Ftoa[F Float](seq iter.Seq[F], format byte, prec int) iter.Seq[string] {
+	bitSize := 64
+	if max32 := F(math.MaxFloat32); math.IsInf(float64(F(max32*2)), 1) {
+		bitSize = 32 // F is float32 or a named ~float32 type
+	}
+	return func(yield func(string) bool) {
+		for x := range seq {
+			if !yield(strconv.FormatFloat(float64(x), format, prec,
+				bitSize)) {
+				return
+			}
+		}
+	}
+}
+
+// Itoa returns an iterator which yields each integer yielded by seq as a
+// decimal string.
+// See also [Ftoa] and [ParseInts]
+func Itoa[I unum.Integer](seq iter.Seq[I]) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for i := range seq {
+			var text string
+			if i < 0 {
+				text = strconv.FormatInt(int64(i), 10)
+			} else {
+				text = strconv.FormatUint(uint64(i), 10)
+			}
+			if !yield(text) {
+				return
+			}
+		}
+	}
+}
+
+// JoinSeq returns a string of the elements yielded by seq each formatted
+// using %v (so a String() method is used if present), separated by sep.
+//
+//	JoinSeq(RangeX(1, 4, 1), ", ") // "1, 2, 3"
+//
+// See also [JoinSeqFunc] and [strings.Join]
+func JoinSeq[E any](seq iter.Seq[E], sep string) string {
+	return JoinSeqFunc(seq, sep, func(element E) string {
+		return fmt.Sprint(element)
+	})
+}
+
+// JoinSeqFunc returns a string of the result of calling the format
+// function on each element yielded by seq, separated by sep.
+// See also [JoinSeq] and [Format]
+func JoinSeqFunc[E any](seq iter.Seq[E], sep string, format func(E) string,
+) string {
+	var text strings.Builder
+	first := true
+	for element := range seq {
+		if first {
+			first = false
+		} else {
+			text.WriteString(sep)
+		}
+		text.WriteString(format(element))
+	}
+	return text.String()
+}
+
+// ParseFloats returns an iterator which yields the result of parsing each
+// string yielded by seq (ignoring leading and trailing whitespace) as a
+// float64, with a nil error. For a string that can't be parsed it yields 0
+// and a [*PipelineError] holding the string's index and wrapping the
+// [strconv.ParseFloat] error, and then continues, so the caller can choose
+// whether to skip bad values or stop (e.g., using [CollectErr]).
+// See also [ParseInts] and [Ftoa]
+func ParseFloats(seq iter.Seq[string]) iter.Seq2[float64, error] {
+	return parse(seq, "ParseFloats", func(text string) (float64, error) {
+		return strconv.ParseFloat(text, 64)
+	})
+}
+
+// ParseInts is like [ParseFloats] except that it parses each string as a
+// decimal int (using [strconv.Atoi]).
+// See also [Itoa]
+func ParseInts(seq iter.Seq[string]) iter.Seq2[int, error] {
+	return parse(seq, "ParseInts", strconv.Atoi)
+}
+
+// SpansBytes returns an iterator which yields subslices of stride bytes
+// from b (the last may be shorter). No bytes are copied.
+// SpansBytes panics if stride is <= 0.
+// See also [Spans] and [SpansString]
+func SpansBytes(b []byte, stride int) iter.Seq[[]byte] {
+	if stride <= 0 {
+		panic(ErrInvalidStride)
+	}
+	return func(yield func([]byte) bool) {
+		for i := 0; i < len(b); i += stride {
+			if !yield(b[i:min(i+stride, len(b))]) {
+				return
+			}
+		}
+	}
+}
+
+// SpansString returns an iterator which yields substrings of stride bytes
+// from s (the last may be shorter), e.g., for fixed-width records. No
+// bytes are copied. A multi-byte UTF-8 character may be split between
+// substrings; use [SpansStringUTF8] to avoid this.
+// SpansString panics if stride is <= 0.
+// See also [SpansBytes]
+func SpansString(s string, stride int) iter.Seq[string] {
+	if stride <= 0 {
+		panic(ErrInvalidStride)
+	}
+	return func(yield func(string) bool) {
+		for i := 0; i < len(s); i += stride {
+			if !yield(s[i:min(i+stride, len(s))]) {
+				return
+			}
+		}
+	}
+}
+
+// SpansStringUTF8 returns an iterator which yields substrings of stride
+// runes (i.e., characters) from s (the last may be shorter), so never
+// splits a multi-byte UTF-8 character. No bytes are copied and s isn't
+// converted to a []rune. (Invalid UTF-8 bytes each count as one rune.)
+// SpansStringUTF8 panics if stride is <= 0.
+// See also [SpansString]
+func SpansStringUTF8(s string, stride int) iter.Seq[string] {
+	if stride <= 0 {
+		panic(ErrInvalidStride)
+	}
+	return func(yield func(string) bool) {
+		start, count := 0, 0
+		for i := 0; i < len(s); {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+			count++
+			if count == stride {
+				if !yield(s[start:i]) {
+					return
+				}
+				start, count = i, 0
+			}
+		}
+		if start < len(s) {
+			yield(s[start:])
+		}
+	}
+}
+
+// WriteTable writes the rows to w as a plain text table with aligned
+// columns (using [text/tabwriter]). Rows may have different numbers of
+// cells; any tabs or newlines in cells are replaced with spaces.
+//
+//	err := WriteTable(os.Stdout, Format(records, toCells), TableOptions{})
+func WriteTable(w io.Writer, rows iter.Seq[[]string], opts TableOptions,
+) error {
+	padding := opts.Padding
+	if padding <= 0 {
+		padding = 2
+	}
+	var flags uint
+	if opts.AlignRight {
+		flags = tabwriter.AlignRight
+	}
+	table := tabwriter.NewWriter(w, opts.MinWidth, 0, padding, ' ', flags)
+	cleaner := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	var line strings.Builder
+	for row := range rows {
+		line.Reset()
+		for i, cell := range row {
+			if i > 0 && !opts.AlignRight {
+				line.WriteByte('\t')
+			}
+			line.WriteString(cleaner.Replace(cell))
+			if opts.AlignRight {
+				line.WriteByte('\t')
+			}
+		}
+		line.WriteByte('\n')
+		if _, err := io.WriteString(table, line.String()); err != nil {
+			return err
+		}
+	}
+	return table.Flush()
+}
+
+func parse[N any](seq iter.Seq[string], stage string,
+	convert func(string) (N, error),
+) iter.Seq2[N, error] {
+	return func(yield func(N, error) bool) {
+		i := 0
+		for text := range seq {
+			value, err := convert(strings.TrimSpace(text))
+			if err != nil {
+				err = &PipelineError{Stage: stage, Index: i, Err: err}
+			}
+			if !yield(value, err) {
+				return
+			}
+			i++
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package ufunc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_JoinSeq(t *testing.T) {
+	if got := JoinSeq(RangeX(1, 4, 1), ", "); got != "1, 2, 3" {
+		t.Errorf("expected 1, 2, 3, got %q", got)
+	}
+	if got := JoinSeq(slices.Values([]time.Duration{time.Second,
+		time.Millisecond}), "|"); got != "1s|1ms" {
+		t.Errorf("expected 1s|1ms, got %q", got)
+	}
+	if got := JoinSeq(slices.Values([]int{}), ","); got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+	hex := func(n int) string { return strconv.FormatInt(int64(n), 16) }
+	if got := JoinSeqFunc(RangeX(9, 13, 1), " ", hex); got != "9 a b c" {
+		t.Errorf("expected 9 a b c, got %q", got)
+	}
+	lines := slices.Collect(Format(RangeX(1, 3, 1), func(n int) string {
+		return "#" + strconv.Itoa(n)
+	}))
+	if exp := []string{"#1", "#2"}; !slices.Equal(exp, lines) {
+		t.Errorf("expected %v, got %v", exp, lines)
+	}
+}
+
+func Test_WriteTable(t *testing.T) {
+	rows := [][]string{{"Name", "Qty", "Price"}, {"apple", "3", "0.5"},
+		{"watermelon", "12", "3.25"}, {"fig\tdried"}}
+	var out strings.Builder
+	if err := WriteTable(&out, slices.Values(rows),
+		TableOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	exp := "Name        Qty  Price\n" +
+		"apple       3    0.5\n" +
+		"watermelon  12   3.25\n" +
+		"fig dried\n"
+	if out.String() != exp {
+		t.Errorf("expected\n%s\ngot\n%s", exp, out.String())
+	}
+	out.Reset()
+	if err := WriteTable(&out, slices.Values(rows[:3]),
+		TableOptions{Padding: 1, AlignRight: true}); err != nil {
+		t.Fatal(err)
+	}
+	exp = "       Name Qty Price\n" + // padding is left of every cell
+		"      apple   3   0.5\n" +
+		" watermelon  12  3.25\n"
+	if out.String() != exp {
+		t.Errorf("expected\n%s\ngot\n%s", exp, out.String())
+	}
+}
+
+func Test_ParseInts(t *testing.T) {
+	lines := slices.Values([]string{"1", " 22 ", "x", "-4"})
+	values := []int{}
+	var errs []error
+	for value, err := range ParseInts(lines) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		values = append(values, value)
+	}
+	if exp := []int{1, 22, -4}; !slices.Equal(exp, values) {
+		t.Errorf("expected %v, got %v", exp, values)
+	}
+	var perr *PipelineError
+	if len(errs) != 1 || !errors.As(errs[0], &perr) || perr.Index != 2 ||
+		perr.Stage != "ParseInts" || !errors.Is(errs[0],
+		strconv.ErrSyntax) {
+		t.Errorf("expected one ParseInts error at 2, got %v", errs)
+	}
+	floats, err := CollectErr(ParseFloats(slices.Values([]string{"1.5",
+		"2e3", "inf"})))
+	if err != nil || !slices.Equal(floats, []float64{1.5, 2000,
+		math.Inf(1)}) {
+		t.Errorf("expected [1.5 2000 +Inf] <nil>, got %v %v", floats, err)
+	}
+	if _, err := CollectErr(ParseFloats(slices.Values([]string{"1",
+		""}))); err == nil || err.Error() !=
+		`stage "ParseFloats": element 1: strconv.ParseFloat: `+
+			`parsing "": invalid syntax` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func Test_ItoaFtoa(t *testing.T) {
+	ints := slices.Collect(Itoa(slices.Values([]int8{-128, 0, 127})))
+	if exp := []string{"-128", "0", "127"}; !slices.Equal(exp, ints) {
+		t.Errorf("expected %v, got %v", exp, ints)
+	}
+	big := slices.Collect(Itoa(slices.Values([]uint64{math.MaxUint64})))
+	if exp := []string{"18446744073709551615"}; !slices.Equal(exp, big) {
+		t.Errorf("expected %v, got %v", exp, big)
+	}
+	floats := slices.Collect(Ftoa(slices.Values([]float32{0.1, 2.5}), 'g',
+		-1))
+	if exp := []string{"0.1", "2.5"}; !slices.Equal(exp, floats) {
+		t.Errorf("expected %v, got %v", exp, floats)
+	}
+	fixed := slices.Collect(Ftoa(slices.Values([]float64{1, 2.345}), 'f',
+		2))
+	if exp := []string{"1.00", "2.35"}; !slices.Equal(exp, fixed) {
+		t.Errorf("expected %v, got %v", exp, fixed)
+	}
+	type celsius float32
+	named := slices.Collect(Ftoa(slices.Values([]celsius{0.1, -40}), 'g',
+		-1))
+	if exp := []string{"0.1", "-40"}; !slices.Equal(exp, named) {
+		t.Errorf("expected %v, got %v", exp, named)
+	}
+}
+
+func Test_SpansString(t *testing.T) {
+	got := slices.Collect(SpansString("ABCDEFGH", 3))
+	if exp := []string{"ABC", "DEF", "GH"}; !slices.Equal(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	bytes := slices.Collect(SpansBytes([]byte("ABCDEF"), 2))
+	if got := fmt.Sprintf("%s", bytes); got != "[AB CD EF]" {
+		t.Errorf("expected [AB CD EF], got %s", got)
+	}
+	got = slices.Collect(SpansStringUTF8("naïve café ☕", 4))
+	if exp := []string{"naïv", "e ca", "fé ☕"}; !slices.Equal(exp, got) {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+	got = slices.Collect(SpansStringUTF8("héllo", 2))
+	if exp := []string{"hé", "ll", "o"}; !slices.Equal(exp, got) {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+	if n := Drain(SpansString("", 3)) + Drain(SpansStringUTF8("", 3)) +
+		Drain(SpansBytes(nil, 3)); n != 0 {
+		t.Errorf("expected no spans, got %d", n)
+	}
+	for span := range SpansStringUTF8("αβγδ", 1) {
+		if span != "α" {
+			t.Errorf("expected α, got %s", span)
+		}
+		break
+	}
+}
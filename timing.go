@@ -0,0 +1,80 @@
+// Copyright © 2026 Mark Summerfield. All rights reserved.
+
+package ufunc
+
+import (
+	"iter"
+	"time"
+)
+
+// Debounce returns an iterator which yields an element from seq only once
+// seq has yielded nothing else for the quiet duration, i.e., each burst of
+// elements is reduced to its last element. When seq finishes any pending
+// element is yielded at once. seq is consumed in a separate goroutine
+// (e.g., it would typically be a [FromChan] of events). If iteration stops
+// before seq finishes, that goroutine only exits when seq next yields (and
+// that element is discarded) or finishes; so after breaking out of a
+// Debounce of a long-lived channel the next event sent to the channel is
+// lost.
+// See also [Throttle]
+func Debounce[E any](seq iter.Seq[E], quiet time.Duration) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		elements := make(chan E)
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			defer close(elements)
+			for element := range seq {
+				select {
+				case elements <- element:
+				case <-done:
+					return
+				}
+			}
+		}()
+		timer := time.NewTimer(quiet)
+		timer.Stop()
+		defer timer.Stop()
+		var pending E
+		hasPending := false
+		for {
+			select {
+			case element, ok := <-elements:
+				if !ok {
+					if hasPending {
+						yield(pending)
+					}
+					return
+				}
+				pending, hasPending = element, true
+				timer.Reset(quiet)
+			case <-timer.C:
+				if hasPending {
+					hasPending = false
+					if !yield(pending) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Throttle returns an iterator which yields the first element from seq,
+// then drops any elements that arrive less than interval after the most
+// recently yielded element, i.e., at most one element is yielded per
+// interval.
+// See also [Debounce]
+func Throttle[E any](seq iter.Seq[E], interval time.Duration) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		var last time.Time
+		for element := range seq {
+			if now := time.Now(); last.IsZero() || now.Sub(last) >= interval {
+				last = now
+				if !yield(element) {
+					return
+				}
+			}
+		}
+	}
+}
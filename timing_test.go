@@ -0,0 +1,72 @@
+package ufunc
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func Test_Debounce(t *testing.T) {
+	// Timing tests only check what must hold however slowly the elements
+	// arrive: a slow sender can split a burst but never merge two bursts.
+	events := make(chan int)
+	go func() {
+		defer close(events)
+		for _, x := range []int{1, 2, 3} {
+			events <- x
+		}
+		time.Sleep(300 * time.Millisecond)
+		events <- 4
+		events <- 5
+	}()
+	got := slices.Collect(Debounce(FromChan(events), 50*time.Millisecond))
+	if !slices.IsSorted(got) || !slices.Contains(got, 3) ||
+		len(got) == 0 || got[len(got)-1] != 5 {
+		t.Errorf("expected [3 5] or a split burst, got %v", got)
+	}
+	infinite := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if i%3 == 0 {
+				time.Sleep(200 * time.Millisecond)
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	for x := range Debounce(infinite, 50*time.Millisecond) {
+		if x > 2 {
+			t.Errorf("expected a value from the first burst, got %d", x)
+		}
+		break
+	}
+}
+
+func Test_Throttle(t *testing.T) {
+	bursts := func(yield func(int) bool) {
+		for i := range 6 {
+			if i == 3 {
+				time.Sleep(300 * time.Millisecond)
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	got := slices.Collect(Throttle(bursts, 100*time.Millisecond))
+	if len(got) == 0 || got[0] != 0 || !slices.Contains(got, 3) {
+		t.Errorf("expected [0 3] or a split burst, got %v", got)
+	}
+	got = slices.Collect(Throttle(slices.Values([]int{7, 8}), 0))
+	if exp := []int{7, 8}; !slices.Equal(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	events := make(chan string, 2)
+	events <- "a"
+	events <- "b"
+	close(events)
+	if got := slices.Collect(FromChan(events)); !slices.Equal(got,
+		[]string{"a", "b"}) {
+		t.Errorf("expected [a b], got %v", got)
+	}
+}
@@ -8,9 +8,16 @@
 package ufunc
 
 import (
+	"cmp"
 	_ "embed"
+	"errors"
+	"fmt"
 	"iter"
+	"math"
+	"math/big"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/mark-summerfield/unum"
 )
@@ -18,6 +25,295 @@ import (
 //go:embed Version.dat
 var Version string
 
+// ErrRagged is returned when rows that should all be the same length
+// aren't.
+var ErrRagged = errors.New("ragged rows")
+
+// ErrInvalidStep is returned by [RangeXE] for an invalid step size.
+var ErrInvalidStep = errors.New("step size must be nonzero")
+
+// ErrInvalidStride is returned by [SpansE] for an invalid stride.
+var ErrInvalidStride = errors.New("stride must be > 0")
+
+// ErrLengthMismatch is returned (wrapped) by [ZipOpt] in strict mode if
+// the iterators yield different numbers of elements.
+var ErrLengthMismatch = errors.New("lengths differ")
+
+// Buckets returns a map whose keys are the distinct keys (as returned by
+// the bucket function) of the values, and whose values are slices of the
+// values with that key, in the order they occur. Unlike [GroupBySlice]
+// this counts each bucket's size first so that all the buckets share a
+// single exactly-sized allocation (each bucket has a capacity equal to its
+// length so appending to one never overwrites another).
+func Buckets[E any, K comparable](values []E, bucket func(E) K) map[K][]E {
+	keys := make([]K, len(values))
+	counts := map[K]int{}
+	for i, x := range values {
+		keys[i] = bucket(x)
+		counts[keys[i]]++
+	}
+	all := make([]E, len(values))
+	buckets := make(map[K][]E, len(counts))
+	start := 0
+	for k, count := range counts {
+		buckets[k] = all[start : start : start+count]
+		start += count
+	}
+	for i, x := range values {
+		buckets[keys[i]] = append(buckets[keys[i]], x)
+	}
+	return buckets
+}
+
+// Count returns how many times value occurs in the values slice.
+// See also [CountFunc]
+func Count[E comparable](values []E, value E) int {
+	count := 0
+	for _, x := range values {
+		if x == value {
+			count++
+		}
+	}
+	return count
+}
+
+// CountFunc returns how many of the values satisfy the found function.
+// See also [Count]
+func CountFunc[E any](values []E, found func(E) bool) int {
+	count := 0
+	for _, x := range values {
+		if found(x) {
+			count++
+		}
+	}
+	return count
+}
+
+// Difference returns a new slice containing the elements of a that aren't
+// in b, in the order they occur in a and without duplicates.
+// See also [DifferenceFunc], [Intersect], [SymmetricDifference], and
+// [Union]
+func Difference[E comparable](a, b []E) []E {
+	return DifferenceFunc(a, b, Identity)
+}
+
+// DifferenceFunc returns a new slice containing the elements of a whose
+// keys (as returned by the key function) aren't the keys of any elements in
+// b, in the order they occur in a and without duplicate keys.
+// See also [Difference]
+func DifferenceFunc[E any, K comparable](a, b []E, key func(E) K) []E {
+	seen := keySet(b, key)
+	difference := make([]E, 0, len(a))
+	for _, x := range a {
+		if k := key(x); !seen[k] {
+			seen[k] = true
+			difference = append(difference, x)
+		}
+	}
+	return difference
+}
+
+// DistributeRoundRobin returns count slices with the first value in the
+// first slice, the second value in the second slice, and so on, wrapping
+// round, so that the slices' lengths differ by at most one.
+// DistributeRoundRobin panics if count is <= 0.
+func DistributeRoundRobin[E any](values []E, count int) [][]E {
+	if count <= 0 {
+		panic("count must be > 0")
+	}
+	shards := make([][]E, count)
+	for i := range shards {
+		shards[i] = make([]E, 0, (len(values)+count-1-i)/count)
+	}
+	for i, x := range values {
+		shards[i%count] = append(shards[i%count], x)
+	}
+	return shards
+}
+
+// ElementsMatch returns true if a and b contain the same elements the same
+// number of times, ignoring order (i.e., they are equal as multisets).
+// See also [ElementsMatchDiff]
+func ElementsMatch[E comparable](a, b []E) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	missing, extra := ElementsMatchDiff(a, b)
+	return len(missing) == 0 && len(extra) == 0
+}
+
+// ElementsMatchDiff compares a (e.g., expected values) with b (e.g., actual
+// values) ignoring order, and returns the elements of a that are missing
+// from b and the extra elements in b that aren't in a, taking account of
+// how many times each element occurs. Both are empty if the elements match.
+// See also [ElementsMatch]
+func ElementsMatchDiff[E comparable](a, b []E) ([]E, []E) {
+	counts := make(map[E]int, len(a))
+	for _, x := range a {
+		counts[x]++
+	}
+	extra := []E{}
+	for _, x := range b {
+		if counts[x] > 0 {
+			counts[x]--
+		} else {
+			extra = append(extra, x)
+		}
+	}
+	missing := []E{}
+	for _, x := range a {
+		if counts[x] > 0 {
+			counts[x]--
+			missing = append(missing, x)
+		}
+	}
+	return missing, extra
+}
+
+// ExtendToLen returns the values slice padded at the end with as many fill
+// elements as are needed to make it size long. If the values slice is
+// already at least size long it is returned unchanged.
+func ExtendToLen[E any](values []E, size int, fill E) []E {
+	if len(values) >= size {
+		return values
+	}
+	values = slices.Grow(values, size-len(values))
+	for len(values) < size {
+		values = append(values, fill)
+	}
+	return values
+}
+
+// FilterInPlace keeps only those values for which the keep function
+// returns true, reusing the values slice's backing array, and returns the
+// shortened slice. Any now unused elements at the end are zeroed. This is
+// the complement of [slices.DeleteFunc].
+// See also [FilterSlice]
+func FilterInPlace[E any](values []E, keep func(E) bool) []E {
+	return slices.DeleteFunc(values, func(x E) bool { return !keep(x) })
+}
+
+// FilterIndexed returns an iterator which yields those values for which the
+// keep function, called with each value's index and the value, returns
+// true.
+//
+//	rows := FilterIndexed(lines, func(i int, _ string) bool { return i > 0 })
+//
+// See also [FilterSlice] and [MapIndexed]
+func FilterIndexed[E any](values []E, keep func(int, E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for i, x := range values {
+			if keep(i, x) && !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSlice returns a new slice containing only those values for which
+// the keep function returns true; the values slice itself is unchanged.
+// See also [FilterInPlace]
+func FilterSlice[E any](values []E, keep func(E) bool) []E {
+	filtered := make([]E, 0, len(values))
+	for _, x := range values {
+		if keep(x) {
+			filtered = append(filtered, x)
+		}
+	}
+	return filtered
+}
+
+// FindLast returns the rightmost value in the slice for which the found
+// function returns true and true, or the zero value and false if there's
+// no such value.
+// See also [LastIndexFunc] and [FindLastSeq]
+func FindLast[E any](values []E, found func(E) bool) (E, bool) {
+	for i := len(values) - 1; i >= 0; i-- {
+		if found(values[i]) {
+			return values[i], true
+		}
+	}
+	var zero E
+	return zero, false
+}
+
+// FindLastSeq returns the last value yielded by seq for which the found
+// function returns true and true, or the zero value and false if there's
+// no such value. It always consumes the whole of seq.
+// See also [FindLast]
+func FindLastSeq[E any](seq iter.Seq[E], found func(E) bool) (E, bool) {
+	var last E
+	ok := false
+	for x := range seq {
+		if found(x) {
+			last, ok = x, true
+		}
+	}
+	return last, ok
+}
+
+// Flatten2D returns a single slice containing all the elements of the
+// first row, then all the elements of the second row, and so on. The
+// result is allocated once at exactly the required size.
+// See also [Spans]
+func Flatten2D[E any](rows [][]E) []E {
+	size := 0
+	for _, row := range rows {
+		size += len(row)
+	}
+	flat := make([]E, 0, size)
+	for _, row := range rows {
+		flat = append(flat, row...)
+	}
+	return flat
+}
+
+// ForEach2D calls the visit function with the row index, column index,
+// and value of every element of rows, row by row. The rows may be ragged.
+// See also [Map2D]
+func ForEach2D[E any](rows [][]E, visit func(row, col int, value E)) {
+	for r, row := range rows {
+		for c, value := range row {
+			visit(r, c, value)
+		}
+	}
+}
+
+// GroupBySlice returns a map whose keys are the distinct keys (as returned
+// by the key function) of the values, and whose values are slices of the
+// values with that key, in the order they occur.
+// See also [IndexBy]
+func GroupBySlice[E any, K comparable](values []E, key func(E) K) map[K][]E {
+	groups := map[K][]E{}
+	for _, x := range values {
+		k := key(x)
+		groups[k] = append(groups[k], x)
+	}
+	return groups
+}
+
+// Halve returns the first half and the second half of the values slice;
+// if the slice has an odd length the second half is one longer. Both
+// halves share the values slice's backing array.
+// See also [SplitAt]
+func Halve[E any](values []E) ([]E, []E) {
+	return SplitAt(values, len(values)/2)
+}
+
+// HasAnyPrefix returns true if the values slice starts with any of the
+// prefixes.
+// See also [IndexAnyPrefix] and [HasAnySuffix]
+func HasAnyPrefix[E comparable](values []E, prefixes ...[]E) bool {
+	return IndexAnyPrefix(values, prefixes...) > -1
+}
+
+// HasAnySuffix returns true if the values slice ends with any of the
+// suffixes.
+// See also [IndexAnySuffix] and [HasAnyPrefix]
+func HasAnySuffix[E comparable](values []E, suffixes ...[]E) bool {
+	return IndexAnySuffix(values, suffixes...) > -1
+}
+
 // HasPrefix returns true if the values slice starts with prefix
 func HasPrefix[E comparable](values, prefix []E) bool {
 	for i := range len(prefix) {
@@ -28,6 +324,28 @@ func HasPrefix[E comparable](values, prefix []E) bool {
 	return true
 }
 
+// HasPrefixFold returns true if the values slice starts with prefix,
+// comparing strings case-insensitively using [strings.EqualFold].
+// See also [HasPrefixFunc]
+func HasPrefixFold(values, prefix []string) bool {
+	return HasPrefixFunc(values, prefix, strings.EqualFold)
+}
+
+// HasPrefixFunc returns true if the values slice starts with prefix, using
+// the equal function to compare elements.
+// See also [HasPrefix] and [HasSuffixFunc]
+func HasPrefixFunc[E any](values, prefix []E, equal func(E, E) bool) bool {
+	if len(prefix) > len(values) {
+		return false
+	}
+	for i, p := range prefix {
+		if !equal(values[i], p) {
+			return false
+		}
+	}
+	return true
+}
+
 // HasSuffix returns true if the values slice ends with prefix
 func HasSuffix[E comparable](values, suffix []E) bool {
 	if len(suffix) > len(values) {
@@ -43,6 +361,254 @@ func HasSuffix[E comparable](values, suffix []E) bool {
 	return true
 }
 
+// HasSuffixFold returns true if the values slice ends with suffix,
+// comparing strings case-insensitively using [strings.EqualFold].
+// See also [HasSuffixFunc]
+func HasSuffixFold(values, suffix []string) bool {
+	return HasSuffixFunc(values, suffix, strings.EqualFold)
+}
+
+// HasSuffixFunc returns true if the values slice ends with suffix, using
+// the equal function to compare elements.
+// See also [HasSuffix] and [HasPrefixFunc]
+func HasSuffixFunc[E any](values, suffix []E, equal func(E, E) bool) bool {
+	if len(suffix) > len(values) {
+		return false
+	}
+	return HasPrefixFunc(values[len(values)-len(suffix):], suffix, equal)
+}
+
+// Head returns (up to) the first n elements of the values slice, which
+// share its backing array. Head never panics: if n <= 0 it returns an
+// empty slice and if n > len(values) it returns the whole slice.
+// See also [LastN], [Init], and [Tail]
+func Head[E any](values []E, n int) []E {
+	return values[:max(0, min(n, len(values)))]
+}
+
+// IndexAll returns the index positions of every occurrence of value in the
+// values slice (an empty slice if there are none).
+// See also [NthIndex] and [slices.Index]
+func IndexAll[E comparable](values []E, value E) []int {
+	indexes := []int{}
+	for i, x := range values {
+		if x == value {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// IndexAnyPrefix returns the index position in prefixes of the first
+// prefix that the values slice starts with, or -1 if it starts with none of
+// them. (To find the longest match, order the prefixes longest first.)
+// See also [HasAnyPrefix]
+func IndexAnyPrefix[E comparable](values []E, prefixes ...[]E) int {
+	for i, prefix := range prefixes {
+		if HasPrefix(values, prefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexAnySuffix returns the index position in suffixes of the first
+// suffix that the values slice ends with, or -1 if it ends with none of
+// them.
+// See also [HasAnySuffix]
+func IndexAnySuffix[E comparable](values []E, suffixes ...[]E) int {
+	for i, suffix := range suffixes {
+		if HasSuffix(values, suffix) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexBy returns a map whose keys are the keys (as returned by the key
+// function) of the values, and whose values are the corresponding values.
+// If more than one value has the same key, the last one wins.
+// See also [GroupBySlice]
+func IndexBy[E any, K comparable](values []E, key func(E) K) map[K]E {
+	index := make(map[K]E, len(values))
+	for _, x := range values {
+		index[key(x)] = x
+	}
+	return index
+}
+
+// Indices2D returns an iterator which yields every (row, column) pair for a
+// grid of the given size in row-major order, i.e., (0, 0), (0, 1), …,
+// (0, cols-1), (1, 0), and so on.
+//
+//	for row, col := range Indices2D(rows, cols) {
+//
+// See also [Indices2DX] and [Indices3D]
+func Indices2D(rows, cols int) iter.Seq2[int, int] {
+	return Indices2DX(rows, cols, 1, 1)
+}
+
+// Indices2DX is like [Indices2D] except that rows advance by rowStep and
+// columns by colStep.
+// Both steps must be > 0 or Indices2DX will panic.
+func Indices2DX(rows, cols, rowStep, colStep int) iter.Seq2[int, int] {
+	if rowStep <= 0 || colStep <= 0 {
+		panic(ErrInvalidStep)
+	}
+	return func(yield func(int, int) bool) {
+		for row := 0; row < rows; row += rowStep {
+			for col := 0; col < cols; col += colStep {
+				if !yield(row, col) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Indices3D returns an iterator which yields every [layer, row, column]
+// triple for a grid of the given size in row-major order.
+// See also [Indices2D]
+func Indices3D(layers, rows, cols int) iter.Seq[[3]int] {
+	return func(yield func([3]int) bool) {
+		for layer := range layers {
+			for row, col := range Indices2D(rows, cols) {
+				if !yield([3]int{layer, row, col}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Init returns all but the last element of the values slice (or an empty
+// slice if values is empty), sharing its backing array.
+// See also [Tail]
+func Init[E any](values []E) []E {
+	return values[:max(0, len(values)-1)]
+}
+
+// InsertSorted inserts value into the sorted values slice at the position
+// that keeps it sorted (found using [slices.BinarySearch]), and returns
+// the modified slice.
+// See also [InsertSortedFunc] and [MergeSortedSlices]
+func InsertSorted[E cmp.Ordered](values []E, value E) []E {
+	i, _ := slices.BinarySearch(values, value)
+	return slices.Insert(values, i, value)
+}
+
+// InsertSortedFunc inserts value into the values slice, which must be
+// sorted according to the cmp function, at the position that keeps it
+// sorted (found using [slices.BinarySearchFunc]), and returns the modified
+// slice.
+// See also [InsertSorted]
+func InsertSortedFunc[E any](values []E, value E,
+	cmp func(a, b E) int,
+) []E {
+	i, _ := slices.BinarySearchFunc(values, value, cmp)
+	return slices.Insert(values, i, value)
+}
+
+// InterleaveSlices returns a new slice containing the first element of
+// each of the given slices, then the second element of each, and so on,
+// stopping as soon as one of the slices runs out.
+// See also [InterleaveSlicesLongest] and [Zip]
+func InterleaveSlices[E any](sources ...[]E) []E {
+	if len(sources) == 0 {
+		return []E{}
+	}
+	shortest := len(sources[0])
+	for _, source := range sources[1:] {
+		shortest = min(shortest, len(source))
+	}
+	interleaved := make([]E, 0, shortest*len(sources))
+	for i := range shortest {
+		for _, source := range sources {
+			interleaved = append(interleaved, source[i])
+		}
+	}
+	return interleaved
+}
+
+// InterleaveSlicesLongest returns a new slice containing the first element
+// of each of the given slices, then the second element of each, and so on.
+// This continues so long as at least one slice has elements, with exhausted
+// slices simply being skipped (so this is an eager version of [Merge]).
+// See also [InterleaveSlices]
+func InterleaveSlicesLongest[E any](sources ...[]E) []E {
+	size := 0
+	longest := 0
+	for _, source := range sources {
+		size += len(source)
+		longest = max(longest, len(source))
+	}
+	interleaved := make([]E, 0, size)
+	for i := range longest {
+		for _, source := range sources {
+			if i < len(source) {
+				interleaved = append(interleaved, source[i])
+			}
+		}
+	}
+	return interleaved
+}
+
+// Intersect returns a new slice containing the elements of a that are also
+// in b, in the order they occur in a and without duplicates.
+// See also [IntersectFunc], [Difference], [SymmetricDifference], and
+// [Union]
+func Intersect[E comparable](a, b []E) []E {
+	return IntersectFunc(a, b, Identity)
+}
+
+// IntersectFunc returns a new slice containing the elements of a whose keys
+// (as returned by the key function) are also the keys of elements in b, in
+// the order they occur in a and without duplicate keys.
+// See also [Intersect]
+func IntersectFunc[E any, K comparable](a, b []E, key func(E) K) []E {
+	wanted := keySet(b, key)
+	intersection := make([]E, 0, min(len(a), len(b)))
+	for _, x := range a {
+		if k := key(x); wanted[k] {
+			delete(wanted, k)
+			intersection = append(intersection, x)
+		}
+	}
+	return intersection
+}
+
+// IsWeekday returns true if t falls on Monday to Friday. It is intended for
+// use with [RangeTimeFunc].
+func IsWeekday(t time.Time) bool {
+	weekday := t.Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
+
+// Labels returns an iterator which yields spreadsheet-style labels made
+// from the letters from to to inclusive, without end: for example,
+// Labels('A', 'Z') yields A B … Z AA AB … AZ BA … ZZ AAA ….
+// Labels panics if to < from.
+// See also [Letters]
+func Labels(from, to rune) iter.Seq[string] {
+	if to < from {
+		panic("to must be >= from")
+	}
+	base := int(to-from) + 1
+	return func(yield func(string) bool) {
+		label := make([]rune, 0, 8)
+		for n := 0; ; n++ {
+			label = label[:0]
+			for i := n; i >= 0; i = i/base - 1 {
+				label = append(label, from+rune(i%base))
+			}
+			slices.Reverse(label)
+			if !yield(string(label)) {
+				return
+			}
+		}
+	}
+}
+
 // LastIndex returns the index position of the rightmost value in the slice
 // or -1 if value isn't in the slice.
 // See also [slices.Index]
@@ -55,6 +621,15 @@ func LastIndex[E comparable](values []E, value E) int {
 	return -1
 }
 
+// LastIndexFold returns the index position of the rightmost string in the
+// slice that is equal to value compared case-insensitively using
+// [strings.EqualFold], or -1 if there's no such string.
+func LastIndexFold(values []string, value string) int {
+	return LastIndexFunc(values, func(s string) bool {
+		return strings.EqualFold(s, value)
+	})
+}
+
 // LastIndex returns the index position of the rightmost value in the slice
 // or -1 if value isn't in the slice.
 // See also [slices.IndexFunc]
@@ -67,6 +642,74 @@ func LastIndexFunc[E any](values []E, found func(E) bool) int {
 	return -1
 }
 
+// LastN returns (up to) the last n elements of the values slice, which
+// share its backing array. LastN never panics: if n <= 0 it returns an
+// empty slice and if n > len(values) it returns the whole slice.
+// See also [Head]
+func LastN[E any](values []E, n int) []E {
+	return values[len(values)-max(0, min(n, len(values))):]
+}
+
+// Letters returns an iterator which yields each rune from from to to
+// inclusive as a string.
+//
+//	for s := range Letters('a', 'e') { // "a" "b" "c" "d" "e"
+//
+// See also [Labels] and [Strings]
+func Letters(from, to rune) iter.Seq[string] {
+	return Strings(RangeIncl(from, to))
+}
+
+// Linspace returns an iterator that yields exactly count evenly spaced
+// numbers from start to end inclusive. Each value is computed directly from
+// its position so there's no accumulated error and the last value is
+// exactly end. If count is 1 only start is yielded.
+// Linspace panics if count is negative.
+//
+//	for x := range Linspace(0, 1, 5) { // 0 0.25 0.5 0.75 1
+//
+// See also [Logspace] and [RangeX]
+func Linspace(start, end float64, count int) iter.Seq[float64] {
+	if count < 0 {
+		panic("count must be >= 0")
+	}
+	return func(yield func(float64) bool) {
+		step := 0.0
+		if count > 1 {
+			step = (end - start) / float64(count-1)
+		}
+		for i := range count {
+			x := start + float64(i)*step
+			if i == count-1 && count > 1 {
+				x = end
+			}
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Logspace returns an iterator that yields exactly count numbers evenly
+// spaced on a log scale from 10^start to 10^end inclusive.
+// Logspace panics if count is negative.
+//
+//	for x := range Logspace(0, 3, 4) { // 1 10 100 1000
+//
+// See also [Linspace]
+func Logspace(start, end float64, count int) iter.Seq[float64] {
+	if count < 0 {
+		panic("count must be >= 0")
+	}
+	return func(yield func(float64) bool) {
+		for x := range Linspace(start, end, count) {
+			if !yield(math.Pow(10, x)) {
+				return
+			}
+		}
+	}
+}
+
 // Map returns an iterator which yields every value in the sources
 // transformed by the mapper function (but dropping any values for which the
 // mapper's ok is false).
@@ -82,6 +725,69 @@ func Map[S, T any](sources []S, mapper func(S) (T, bool)) iter.Seq[T] {
 	}
 }
 
+// Map2D returns a new slice of rows with the same shape as rows (which may
+// be ragged) with every value transformed by the mapper function.
+// See also [ForEach2D] and [MapTo]
+func Map2D[S, T any](rows [][]S, mapper func(S) T) [][]T {
+	mapped := make([][]T, len(rows))
+	for r, row := range rows {
+		mapped[r] = make([]T, len(row))
+		for c, value := range row {
+			mapped[r][c] = mapper(value)
+		}
+	}
+	return mapped
+}
+
+// MapIndexed returns an iterator which yields every value in the sources
+// transformed by the mapper function, which is called with each value's
+// index and the value (but dropping any values for which the mapper's ok
+// is false).
+// See also [Map] and [FilterIndexed]
+func MapIndexed[S, T any](sources []S, mapper func(int, S) (T, bool),
+) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i, source := range sources {
+			if target, ok := mapper(i, source); ok {
+				if !yield(target) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// MapTo writes every value in the sources transformed by the mapper
+// function into dst (reusing dst's backing array if it has enough capacity)
+// and returns dst resliced to len(sources). This is an allocation-free
+// alternative to [Map] for hot loops where a dst buffer can be reused.
+// See also [Transform]
+func MapTo[S, T any](dst []T, sources []S, mapper func(S) T) []T {
+	dst = slices.Grow(dst[:0], len(sources))[:len(sources)]
+	for i, source := range sources {
+		dst[i] = mapper(source)
+	}
+	return dst
+}
+
+// MaxBy returns the (first) value with the largest score (as returned by
+// the score function) and true, or the zero value and false if values is
+// empty. The score function is called once per value.
+// See also [MinBy], [MaxBySeq], and [ArgMax]
+func MaxBy[E any, K cmp.Ordered](values []E, score func(E) K) (E, bool) {
+	return MaxBySeq(slices.Values(values), score)
+}
+
+// MaxBySeq returns the (first) value yielded by seq with the largest score
+// (as returned by the score function) and true, or the zero value and false
+// if seq yields nothing.
+// See also [MaxBy]
+func MaxBySeq[E any, K cmp.Ordered](seq iter.Seq[E], score func(E) K) (E,
+	bool,
+) {
+	return bestBy(seq, score, func(a, b K) bool { return a > b })
+}
+
 // Merge accepts any number of iterators (rangefuncs) and returns a single
 // iterator that yields all the first iterator's elements, then all the
 // second iterator's, and so on.
@@ -111,43 +817,353 @@ func Merge[E any](rfns ...iter.Seq[E]) iter.Seq[E] {
 	}
 }
 
-// Range is a range function that returns a function that
-// returns numbers from start upto (or downto) the step
-// before end in steps of 1.
-//
-//	for x := range Range(5, 15) { // 5 6 7 … 14
-func Range[N unum.SignedNumber](start, end N) iter.Seq[N] {
-	return RangeX(start, end, 1)
+// MergeSortedSlices returns a new sorted slice containing all the elements
+// of the sorted slices a and b. Where elements are equal, those from a come
+// first.
+// See also [MergeSortedSlicesFunc] and [InsertSorted]
+func MergeSortedSlices[E cmp.Ordered](a, b []E) []E {
+	return MergeSortedSlicesFunc(a, b, cmp.Compare[E])
 }
 
-// RangeX is a rang function that returns a function that
-// returns numbers from start upto (or downto) the step
-// before end in steps of step.
-// The step must be a magnitude > 0 or RangeX will panic.
-//
-//	for x := range RangeX(1.0, 8.5, 0.5) { // 1.0 1.5 2.0 … 8.0
-func RangeX[N unum.SignedNumber](start, end, step N) iter.Seq[N] {
-	if step <= 0 {
-		panic("step size must be > 0")
+// MergeSortedSlicesFunc returns a new slice containing all the elements of
+// the slices a and b (which must both be sorted according to the cmp
+// function) sorted according to the cmp function. Where elements are
+// equal, those from a come first.
+// See also [MergeSortedSlices]
+func MergeSortedSlicesFunc[E any](a, b []E, cmp func(a, b E) int) []E {
+	merged := make([]E, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			merged = append(merged, b[j])
+			j++
+		} else {
+			merged = append(merged, a[i])
+			i++
+		}
 	}
-	return func(yield func(N) bool) {
-		if start <= end {
-			for ; start < end; start += step {
-				if !yield(start) {
-					return
+	merged = append(merged, a[i:]...)
+	return append(merged, b[j:]...)
+}
+
+// MinBy returns the (first) value with the smallest score (as returned by
+// the score function) and true, or the zero value and false if values is
+// empty. The score function is called once per value.
+// See also [MaxBy], [MinBySeq], and [ArgMin]
+func MinBy[E any, K cmp.Ordered](values []E, score func(E) K) (E, bool) {
+	return MinBySeq(slices.Values(values), score)
+}
+
+// MinBySeq returns the (first) value yielded by seq with the smallest score
+// (as returned by the score function) and true, or the zero value and false
+// if seq yields nothing.
+// See also [MinBy]
+func MinBySeq[E any, K cmp.Ordered](seq iter.Seq[E], score func(E) K) (E,
+	bool,
+) {
+	return bestBy(seq, score, func(a, b K) bool { return a < b })
+}
+
+// NthIndex returns the index position of the nth occurrence of value in
+// the values slice counting from 0, or -1 if there are not that many
+// occurrences. A negative n counts from the end, so NthIndex(values, v, 0)
+// is the same as [slices.Index] and NthIndex(values, v, -1) is the same as
+// [LastIndex].
+// See also [IndexAll]
+func NthIndex[E comparable](values []E, value E, n int) int {
+	if n >= 0 {
+		for i, x := range values {
+			if x == value {
+				if n == 0 {
+					return i
 				}
+				n--
 			}
-		} else {
-			for ; start > end; start -= step {
-				if !yield(start) {
-					return
+		}
+	} else {
+		for i := len(values) - 1; i >= 0; i-- {
+			if values[i] == value {
+				n++
+				if n == 0 {
+					return i
 				}
 			}
 		}
 	}
+	return -1
 }
 
-// Reduce returns the accumulated elements based on the reduce function and
+// PartitionPoint returns the index of the first value for which the
+// predicate returns false, given that the values are partitioned so that
+// the predicate returns true for every value before that point and false
+// for every value from it onwards. It uses a binary search so calls the
+// predicate O(log n) times. If the predicate is true for every value it
+// returns len(values).
+//
+//	i := PartitionPoint(sorted, func(x int) bool { return x < 10 })
+//
+// See also [PartitionPointFunc], [PartitionPointSeq], and
+// [slices.BinarySearch]
+func PartitionPoint[E any](values []E, predicate func(E) bool) int {
+	return PartitionPointFunc(len(values), func(i int) bool {
+		return predicate(values[i])
+	})
+}
+
+// PartitionPointFunc is like [PartitionPoint] except that it works on the
+// indexes 0 to size-1 of data that may be computed or generated rather than
+// held in a slice, calling predicate(i) for the index i of the item to
+// test. It returns size if the predicate is true for every index.
+// See also [sort.Search]
+func PartitionPointFunc(size int, predicate func(int) bool) int {
+	lo, hi := 0, size
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if predicate(mid) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// PartitionPointSeq is like [PartitionPoint] except that it works on the
+// values yielded by seq. Since seq can't be randomly accessed, it scans
+// linearly, consuming seq up to and including the first value for which
+// the predicate returns false, and returns that value's index (or the
+// number of values yielded if the predicate is true for them all).
+func PartitionPointSeq[E any](seq iter.Seq[E], predicate func(E) bool) int {
+	i := 0
+	for x := range seq {
+		if !predicate(x) {
+			break
+		}
+		i++
+	}
+	return i
+}
+
+// Range is a range function that returns a function that
+// returns numbers from start upto (or downto) the step
+// before end in steps of 1.
+//
+//	for x := range Range(5, 15) { // 5 6 7 … 14
+func Range[N unum.SignedNumber](start, end N) iter.Seq[N] {
+	return RangeX(start, end, 1)
+}
+
+// RangeBig is a range function that returns a function that returns big
+// integers from start upto (or downto) the step before end in steps of
+// step, following the same rules as [RangeX]. Every value yielded is a new
+// *big.Int, and start, end, and step are never modified.
+// The step must be nonzero or RangeBig will panic.
+//
+//	for x := range RangeBig(big.NewInt(0), big.NewInt(9), big.NewInt(3)) {
+//		// 0 3 6
+func RangeBig(start, end, step *big.Int) iter.Seq[*big.Int] {
+	if step.Sign() == 0 {
+		panic(ErrInvalidStep)
+	}
+	delta := new(big.Int).Set(step)
+	ascending := start.Cmp(end) <= 0
+	if step.Sign() < 0 {
+		ascending = false
+	} else if !ascending {
+		delta.Neg(delta)
+	}
+	return func(yield func(*big.Int) bool) {
+		if step.Sign() < 0 && start.Cmp(end) < 0 {
+			return
+		}
+		for x := new(big.Int).Set(start); ; x = new(big.Int).Add(x, delta) {
+			c := x.Cmp(end)
+			if c == 0 || ascending && c > 0 || !ascending && c < 0 ||
+				!yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// RangeFrom is a range function that returns a function that returns
+// numbers from start in steps of step (which may be negative) without end,
+// so it must be used with something that stops, such as a break or [Zip].
+// For floating-point numbers each value is computed as start + i×step so
+// that errors don't accumulate.
+// The step must be nonzero or RangeFrom will panic.
+//
+//	for x := range RangeFrom(1, 2) { // 1 3 5 7 …
+func RangeFrom[N unum.SignedNumber](start, step N) iter.Seq[N] {
+	if step == 0 {
+		panic(ErrInvalidStep)
+	}
+	float := isFloat[N]()
+	return func(yield func(N) bool) {
+		x := start
+		for i := 1; ; i++ {
+			if !yield(x) {
+				return
+			}
+			if float {
+				x = start + N(i)*step
+			} else {
+				x += step
+			}
+		}
+	}
+}
+
+// RangeIncl is like [Range] except that end is also returned if it is
+// reached exactly.
+//
+//	for x := range RangeIncl(5, 15) { // 5 6 7 … 15
+func RangeIncl[N unum.SignedNumber](start, end N) iter.Seq[N] {
+	return RangeXIncl(start, end, 1)
+}
+
+// RangeN is a range function that returns a function that returns exactly
+// count numbers starting from start in steps of step (which may be
+// negative). For floating-point numbers each value is computed as
+// start + i×step so that errors don't accumulate.
+// RangeN panics if count is negative.
+//
+//	for x := range RangeN(1.0, 4, 0.5) { // 1.0 1.5 2.0 2.5
+func RangeN[N unum.SignedNumber](start N, count int, step N) iter.Seq[N] {
+	if count < 0 {
+		panic("count must be >= 0")
+	}
+	float := isFloat[N]()
+	return func(yield func(N) bool) {
+		x := start
+		for i := 1; i <= count; i++ {
+			if !yield(x) {
+				return
+			}
+			if float {
+				x = start + N(i)*step
+			} else {
+				x += step
+			}
+		}
+	}
+}
+
+// RangeTime is a range function that returns a function that returns
+// times from start upto (or downto) the step before end in steps of step,
+// following the same rules as [RangeX].
+// The step must be nonzero or RangeTime will panic.
+//
+//	for t := range RangeTime(monday, monday.AddDate(0, 0, 7), 24*time.Hour) {
+//
+// See also [RangeTimeFunc]
+func RangeTime(start, end time.Time, step time.Duration) iter.Seq[time.Time] {
+	return RangeTimeFunc(start, end, step, nil)
+}
+
+// RangeTimeFunc is like [RangeTime] except that only those times for which
+// the keep function returns true are yielded; for example, passing
+// [IsWeekday] yields only business days. A nil keep function keeps every
+// time.
+// The step must be nonzero or RangeTimeFunc will panic.
+func RangeTimeFunc(start, end time.Time, step time.Duration,
+	keep func(time.Time) bool,
+) iter.Seq[time.Time] {
+	if step == 0 {
+		panic(ErrInvalidStep)
+	}
+	ascending := !start.After(end)
+	if step < 0 {
+		ascending = false
+	} else if !ascending {
+		step = -step
+	}
+	return func(yield func(time.Time) bool) {
+		if step < 0 && start.Before(end) {
+			return
+		}
+		for i := time.Duration(0); ; i++ {
+			t := start.Add(i * step)
+			if ascending && !t.Before(end) || !ascending && !t.After(end) {
+				return
+			}
+			if (keep == nil || keep(t)) && !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// RangeUint is like [Range] but for unsigned integers.
+//
+//	for x := range RangeUint(uint64(5), 15) { // 5 6 7 … 14
+func RangeUint[N Unsigned](start, end N) iter.Seq[N] {
+	return RangeUintX(start, end, 1)
+}
+
+// RangeUintX is like [RangeX] but for unsigned integers, so the direction
+// is always inferred from start and end. Descending ranges never wrap
+// around below 0 and ascending ranges never overflow.
+// The step must be > 0 or RangeUintX will panic.
+//
+//	for x := range RangeUintX(uint8(250), 3, 100) { // 250 150 50
+func RangeUintX[N Unsigned](start, end, step N) iter.Seq[N] {
+	if step == 0 {
+		panic(ErrInvalidStep)
+	}
+	return func(yield func(N) bool) {
+		if start <= end {
+			for x := start; x < end; x += step {
+				if !yield(x) || end-x <= step {
+					return
+				}
+			}
+		} else {
+			for x := start; x > end; x -= step {
+				if !yield(x) || x-end <= step {
+					return
+				}
+			}
+		}
+	}
+}
+
+// RangeX is a rang function that returns a function that
+// returns numbers from start upto (or downto) the step
+// before end in steps of step.
+// If step is positive, the direction is inferred from start and end (so
+// RangeX(10, 0, 2) yields 10 8 6 4 2). If step is negative the range is
+// descending, as in Python's range (so RangeX(0, 10, -2) yields nothing).
+// The step must be nonzero or RangeX will panic.
+// For floating-point numbers each value is computed as start + i×step
+// (rather than by repeatedly adding step) so that errors don't accumulate,
+// and the number of values is computed up front so that a value within a
+// tiny fraction of a step of end counts as end (and so isn't yielded).
+//
+//	for x := range RangeX(1.0, 8.5, 0.5) { // 1.0 1.5 2.0 … 8.0
+func RangeX[N unum.SignedNumber](start, end, step N) iter.Seq[N] {
+	return mustRange(rangeX(start, end, step, false))
+}
+
+// RangeXE is like [RangeX] except that instead of panicking if the step is
+// invalid it returns a nil iterator and [ErrInvalidStep].
+func RangeXE[N unum.SignedNumber](start, end, step N) (iter.Seq[N],
+	error,
+) {
+	return rangeX(start, end, step, false)
+}
+
+// RangeXIncl is like [RangeX] except that end is also returned if it is
+// reached exactly (or for floating-point numbers, to within a tiny fraction
+// of a step, in which case end itself is the last value).
+// The step must be nonzero or RangeXIncl will panic.
+//
+//	for x := range RangeXIncl(0.0, 0.3, 0.1) { // 0.0 0.1 0.2 0.3
+func RangeXIncl[N unum.SignedNumber](start, end, step N) iter.Seq[N] {
+	return mustRange(rangeX(start, end, step, true))
+}
+
+// Reduce returns the accumulated elements based on the reduce function and
 // the initial accumulator value.
 func Reduce[E, A any](elements []E, reduce func(E, A) A, accumulator A) A {
 	for _, element := range elements {
@@ -156,17 +1172,505 @@ func Reduce[E, A any](elements []E, reduce func(E, A) A, accumulator A) A {
 	return accumulator
 }
 
+// Reduce1 returns the result of combining the values from left to right
+// using the reduce function, with the first value as the initial
+// accumulator, and true; or the zero value and false if values is empty.
+// This avoids needing an identity value, e.g., for min, max, or concat.
+//
+//	joined, ok := Reduce1(words, func(a, b string) string {
+//		return a + ", " + b
+//	})
+//
+// See also [Reduce]
+func Reduce1[E any](values []E, reduce func(acc, value E) E) (E, bool) {
+	if len(values) == 0 {
+		var zero E
+		return zero, false
+	}
+	accumulator := values[0]
+	for _, value := range values[1:] {
+		accumulator = reduce(accumulator, value)
+	}
+	return accumulator, true
+}
+
+// ReduceRight is like [Reduce] except that it accumulates the elements
+// from last to first.
+func ReduceRight[E, A any](elements []E, reduce func(E, A) A,
+	accumulator A,
+) A {
+	for _, element := range slices.Backward(elements) {
+		accumulator = reduce(element, accumulator)
+	}
+	return accumulator
+}
+
+// RemoveAll removes every occurrence of value from the values slice in
+// place and returns the shortened slice. Any now unused elements at the end
+// are zeroed.
+// See also [RemovedAll]
+func RemoveAll[E comparable](values []E, value E) []E {
+	return slices.DeleteFunc(values, func(x E) bool { return x == value })
+}
+
+// RemovedAll returns a copy of the values slice with every occurrence of
+// value removed; the values slice itself is unchanged.
+// See also [RemoveAll]
+func RemovedAll[E comparable](values []E, value E) []E {
+	removed := make([]E, 0, len(values))
+	for _, x := range values {
+		if x != value {
+			removed = append(removed, x)
+		}
+	}
+	return removed
+}
+
+// RepeatSlice returns a new slice containing count copies of the values
+// slice, one after the other. RepeatSlice panics if count is negative.
+// See also [slices.Repeat]
+func RepeatSlice[E any](values []E, count int) []E {
+	if count < 0 {
+		panic("count must be >= 0")
+	}
+	repeated := make([]E, 0, len(values)*count)
+	for range count {
+		repeated = append(repeated, values...)
+	}
+	return repeated
+}
+
+// ReplaceAll replaces every occurrence of old with new in the values slice
+// in place and returns the values slice.
+// See also [ReplacedAll] and [ReplaceAllSlice]
+func ReplaceAll[E comparable](values []E, old, new E) []E {
+	for i, x := range values {
+		if x == old {
+			values[i] = new
+		}
+	}
+	return values
+}
+
+// ReplaceAllSlice replaces every non-overlapping occurrence of the old
+// subslice with the new subslice and returns the result. If new is no
+// longer than old the replacements are done in place (zeroing any now
+// unused elements at the end); otherwise a new slice is returned. If old
+// is empty the values slice is returned unchanged.
+// See also [ReplacedAllSlice]
+func ReplaceAllSlice[E comparable](values, old, new []E) []E {
+	if len(old) == 0 {
+		return values
+	}
+	if len(new) > len(old) {
+		return ReplacedAllSlice(values, old, new)
+	}
+	j := 0
+	for i := 0; i < len(values); {
+		if HasPrefix(values[i:], old) {
+			j += copy(values[j:], new)
+			i += len(old)
+		} else {
+			values[j] = values[i]
+			j++
+			i++
+		}
+	}
+	clear(values[j:])
+	return values[:j]
+}
+
+// ReplacedAll returns a copy of the values slice with every occurrence of
+// old replaced by new; the values slice itself is unchanged.
+// See also [ReplaceAll]
+func ReplacedAll[E comparable](values []E, old, new E) []E {
+	return ReplaceAll(slices.Clone(values), old, new)
+}
+
+// ReplacedAllSlice returns a copy of the values slice with every
+// non-overlapping occurrence of the old subslice replaced by the new
+// subslice; the values slice itself is unchanged. If old is empty a plain
+// copy is returned.
+// See also [ReplaceAllSlice]
+func ReplacedAllSlice[E comparable](values, old, new []E) []E {
+	if len(old) == 0 {
+		return slices.Clone(values)
+	}
+	replaced := make([]E, 0, len(values))
+	for i := 0; i < len(values); {
+		if HasPrefix(values[i:], old) {
+			replaced = append(replaced, new...)
+			i += len(old)
+		} else {
+			replaced = append(replaced, values[i])
+			i++
+		}
+	}
+	return replaced
+}
+
+// Rotate rotates the values slice in place by n positions to the right, so
+// the element at index 0 moves to index n. A negative n rotates to the left.
+// Any n (including n > len(values)) is reduced modulo len(values).
+//
+//	values := []int{1, 2, 3, 4, 5}
+//	Rotate(values, 2) // values: [4 5 1 2 3]
+//
+// See also [Rotated], [RotateLeft], and [RotateRight]
+func Rotate[E any](values []E, n int) {
+	size := len(values)
+	if size == 0 {
+		return
+	}
+	n %= size
+	if n < 0 {
+		n += size
+	}
+	if n == 0 {
+		return
+	}
+	slices.Reverse(values)
+	slices.Reverse(values[:n])
+	slices.Reverse(values[n:])
+}
+
+// RotateLeft rotates the values slice in place by n positions to the left.
+// See also [Rotate]
+func RotateLeft[E any](values []E, n int) {
+	Rotate(values, -n)
+}
+
+// RotateRight rotates the values slice in place by n positions to the
+// right.
+// See also [Rotate]
+func RotateRight[E any](values []E, n int) {
+	Rotate(values, n)
+}
+
+// Rotated returns a copy of the values slice rotated by n positions to the
+// right (or to the left if n is negative); the values slice itself is
+// unchanged.
+// See also [Rotate]
+func Rotated[E any](values []E, n int) []E {
+	rotated := slices.Clone(values)
+	Rotate(rotated, n)
+	return rotated
+}
+
+// SpanBounds returns an iterator which yields the start (inclusive) and
+// end (exclusive) indexes of consecutive spans of stride size covering
+// length elements; the last span may be short. This is useful for
+// splitting work on a slice (or on several parallel slices) into chunks.
+// SpanBounds panics if stride is <= 0.
+//
+//	for start, end := range SpanBounds(7, 3) { // 0 3, 3 6, 6 7
+//
+// See also [Spans] and [SpansIndexed]
+func SpanBounds(length, stride int) iter.Seq2[int, int] {
+	if stride <= 0 {
+		panic(ErrInvalidStride)
+	}
+	return func(yield func(int, int) bool) {
+		for i := 0; i < length; i += stride {
+			if !yield(i, min(i+stride, length)) {
+				return
+			}
+		}
+	}
+}
+
 // Returns subslices of stride size from the given slice. Each subslice is
 // returned with true, except possibly the last which if short is returned
 // with false.
+// The stride must be > 0 or Spans will panic.
+// See also [SpansE] and [SpansX]
 func Spans[T any](slice []T, stride int) iter.Seq2[[]T, bool] {
+	spans, err := SpansE(slice, stride)
+	if err != nil {
+		panic(err.Error())
+	}
+	return spans
+}
+
+// SpansE is like [Spans] except that instead of panicking if stride is
+// <= 0 it returns a nil iterator and [ErrInvalidStride].
+func SpansE[T any](slice []T, stride int) (iter.Seq2[[]T, bool], error) {
 	if stride <= 0 {
-		panic("stride must be > 0")
+		return nil, ErrInvalidStride
+	}
+	return SpansX(slice, stride, stride), nil
+}
+
+// SpansIndexed returns an iterator which yields the start index of each
+// subslice of stride size from the given slice and the subslice itself
+// (the last may be short), so that results can be written back to the
+// corresponding positions.
+// SpansIndexed panics if stride is <= 0.
+// See also [Spans] and [SpanBounds]
+func SpansIndexed[T any](slice []T, stride int) iter.Seq2[int, []T] {
+	if stride <= 0 {
+		panic(ErrInvalidStride)
+	}
+	return func(yield func(int, []T) bool) {
+		for start, end := range SpanBounds(len(slice), stride) {
+			if !yield(start, slice[start:end]) {
+				return
+			}
+		}
+	}
+}
+
+// SpansPadded returns an iterator which yields subslices of stride size
+// from the given slice. If the last subslice would be short, a new slice
+// is yielded instead holding the remaining elements padded with fill, so
+// every subslice yielded has exactly stride elements.
+// SpansPadded panics if stride is <= 0.
+// See also [Spans] and [SpansStrict]
+func SpansPadded[T any](slice []T, stride int, fill T) iter.Seq[[]T] {
+	spans := Spans(slice, stride)
+	return func(yield func([]T) bool) {
+		for span, ok := range spans {
+			if !ok {
+				span = ExtendToLen(slices.Clone(span), stride, fill)
+			}
+			if !yield(span) {
+				return
+			}
+		}
+	}
+}
+
+// SpansStrict returns an iterator which yields subslices of stride size
+// from the given slice, dropping any short last subslice, so every
+// subslice yielded has exactly stride elements.
+// SpansStrict panics if stride is <= 0.
+// See also [Spans] and [SpansPadded]
+func SpansStrict[T any](slice []T, stride int) iter.Seq[[]T] {
+	spans := Spans(slice, stride)
+	return func(yield func([]T) bool) {
+		for span, ok := range spans {
+			if !ok || !yield(span) {
+				return
+			}
+		}
+	}
+}
+
+// SpansX returns subslices of size elements from the given slice, the
+// first starting at index 0 and each subsequent one step elements after the
+// previous one's start. If step < size the subslices overlap (e.g., for
+// sliding windows); if step > size elements are skipped between them (e.g.,
+// for decimation); and if step == size SpansX is the same as [Spans]. Each
+// subslice is returned with true, except possibly the last which if short
+// is returned with false. Iteration stops once a subslice reaches the end
+// of the slice.
+// SpansX panics with [ErrInvalidStride] if size or step is <= 0.
+//
+//	for span, ok := range SpansX([]int{1, 2, 3, 4, 5}, 3, 2) {
+//		// [1 2 3]:true [3 4 5]:true
+//
+// See also [Spans] and [Windows]
+func SpansX[T any](slice []T, size, step int) iter.Seq2[[]T, bool] {
+	if size <= 0 || step <= 0 {
+		panic(ErrInvalidStride)
 	}
 	return func(yield func([]T, bool) bool) {
-		for i := 0; i < len(slice); i += stride {
-			subslice := slice[i:min(i+stride, len(slice))]
-			if !yield(subslice, len(subslice) == stride) {
+		for i := 0; i < len(slice); i += step {
+			end := i + size
+			subslice := slice[i:min(end, len(slice))]
+			if !yield(subslice, len(subslice) == size) ||
+				end >= len(slice) {
+				return
+			}
+		}
+	}
+}
+
+// SplitAt returns the elements of the values slice before index i and the
+// elements from index i onwards; both share the values slice's backing
+// array (but the first's capacity is limited so that appending to it won't
+// overwrite the second). A negative i counts from the end (so -1 splits off
+// the last element), and out of range indexes are clamped so SplitAt never
+// panics.
+// See also [Halve]
+func SplitAt[E any](values []E, i int) ([]E, []E) {
+	if i < 0 {
+		i += len(values)
+	}
+	i = max(0, min(i, len(values)))
+	return values[:i:i], values[i:]
+}
+
+// Strings returns an iterator which yields each rune yielded by seq as a
+// string.
+// See also [Letters]
+func Strings(seq iter.Seq[rune]) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for r := range seq {
+			if !yield(string(r)) {
+				return
+			}
+		}
+	}
+}
+
+// SymmetricDifference returns a new slice containing the elements of a
+// that aren't in b followed by the elements of b that aren't in a, in the
+// order they occur and without duplicates.
+// See also [SymmetricDifferenceFunc], [Difference], [Intersect], and
+// [Union]
+func SymmetricDifference[E comparable](a, b []E) []E {
+	return SymmetricDifferenceFunc(a, b, Identity)
+}
+
+// SymmetricDifferenceFunc returns a new slice containing the elements of a
+// whose keys (as returned by the key function) aren't the keys of any
+// elements in b, followed by the elements of b whose keys aren't the keys
+// of any elements in a, in the order they occur and without duplicate
+// keys.
+// See also [SymmetricDifference]
+func SymmetricDifferenceFunc[E any, K comparable](a, b []E,
+	key func(E) K,
+) []E {
+	return append(DifferenceFunc(a, b, key), DifferenceFunc(b, a, key)...)
+}
+
+// Tail returns all but the first element of the values slice (or an empty
+// slice if values is empty), sharing its backing array.
+// See also [Init]
+func Tail[E any](values []E) []E {
+	return values[min(1, len(values)):]
+}
+
+// Transform replaces every value in the values slice in place with the
+// result of calling the transform function on it.
+// See also [MapTo]
+func Transform[E any](values []E, transform func(E) E) {
+	for i, x := range values {
+		values[i] = transform(x)
+	}
+}
+
+// Transpose returns the rows transposed, i.e., a slice of columns, so that
+// Transpose(rows)[c][r] == rows[r][c]. If the rows are not all the same
+// length, returns nil and [ErrRagged].
+// See also [TransposeLongest] and [Zip]
+func Transpose[E any](rows [][]E) ([][]E, error) {
+	if len(rows) == 0 {
+		return [][]E{}, nil
+	}
+	width := len(rows[0])
+	for _, row := range rows[1:] {
+		if len(row) != width {
+			return nil, ErrRagged
+		}
+	}
+	return TransposeLongest(rows), nil
+}
+
+// TransposeLongest returns the rows transposed, i.e., a slice of columns,
+// so that TransposeLongest(rows)[c][r] == rows[r][c]. If the rows are not
+// all the same length, every column has an element for every row, with
+// short rows' missing elements being replaced with their zero values.
+// See also [Transpose] and [ZipLongest]
+func TransposeLongest[E any](rows [][]E) [][]E {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	columns := make([][]E, width)
+	for c := range columns {
+		columns[c] = make([]E, len(rows))
+		for r, row := range rows {
+			if c < len(row) {
+				columns[c][r] = row[c]
+			}
+		}
+	}
+	return columns
+}
+
+// Union returns a new slice containing the elements of a followed by the
+// elements of b that aren't in a, in the order they occur and without
+// duplicates.
+// See also [UnionFunc], [Difference], [Intersect], and
+// [SymmetricDifference]
+func Union[E comparable](a, b []E) []E {
+	return UnionFunc(a, b, Identity)
+}
+
+// UnionFunc returns a new slice containing the elements of a followed by
+// the elements of b, in the order they occur and without duplicate keys (as
+// returned by the key function).
+// See also [Union]
+func UnionFunc[E any, K comparable](a, b []E, key func(E) K) []E {
+	seen := make(map[K]bool, len(a)+len(b))
+	union := make([]E, 0, len(a)+len(b))
+	for _, values := range [][]E{a, b} {
+		for _, x := range values {
+			if k := key(x); !seen[k] {
+				seen[k] = true
+				union = append(union, x)
+			}
+		}
+	}
+	return union
+}
+
+// UniqueBy returns a new slice containing the first element for each
+// distinct key (as returned by the key function), in the order they occur.
+// See also [UniqueSlice]
+func UniqueBy[E any, K comparable](values []E, key func(E) K) []E {
+	seen := make(map[K]bool, len(values))
+	unique := make([]E, 0, len(values))
+	for _, x := range values {
+		if k := key(x); !seen[k] {
+			seen[k] = true
+			unique = append(unique, x)
+		}
+	}
+	return unique
+}
+
+// UniqueSlice returns a new slice containing the first occurrence of each
+// distinct element, in the order they occur. Unlike [slices.Compact] the
+// values don't need to be sorted.
+// See also [UniqueBy]
+func UniqueSlice[E comparable](values []E) []E {
+	return UniqueBy(values, Identity)
+}
+
+// WindowAggregate returns a new slice containing the result of calling the
+// aggregate function on every window of size consecutive values (see
+// [Windows]). The result is empty if size > len(values).
+// WindowAggregate panics if size is <= 0.
+// See also [MovingAverage]
+func WindowAggregate[E, T any](values []E, size int,
+	aggregate func([]E) T,
+) []T {
+	aggregates := make([]T, 0, max(0, len(values)-size+1))
+	for window := range Windows(values, size) {
+		aggregates = append(aggregates, aggregate(window))
+	}
+	return aggregates
+}
+
+// Windows returns an iterator which yields every overlapping subslice of
+// size consecutive elements from the given slice, i.e., slice[0:size],
+// slice[1:size+1], and so on. Nothing is yielded if size > len(slice).
+// The subslices share the slice's backing array.
+// Windows panics if size is <= 0.
+//
+//	for w := range Windows([]int{1, 2, 3, 4}, 3) { // [1 2 3] [2 3 4]
+//
+// See also [Spans]
+func Windows[T any](slice []T, size int) iter.Seq[[]T] {
+	if size <= 0 {
+		panic("size must be > 0")
+	}
+	return func(yield func([]T) bool) {
+		for i := 0; i+size <= len(slice); i++ {
+			if !yield(slice[i : i+size]) {
 				return
 			}
 		}
@@ -177,24 +1681,90 @@ func Spans[T any](slice []T, stride int) iter.Seq2[[]T, bool] {
 // iterator that returns a slice of all the first elements from all the
 // iterators, then a slice of all the second elements, and so on, stopping
 // as soon as one of the iterators runs out.
+// See also [ZipLongest], [ZipOpt], [ZipReuse], and [ZipSeq2]
 func Zip[E any](rfns ...iter.Seq[E]) iter.Seq[[]E] {
-	return func(yield func([]E) bool) {
+	return zipRows(ZipOpt(ZipOptions[E]{}, rfns...))
+}
+
+// ZipIndexed is like [Zip] except that it also yields each row's index
+// (0, 1, 2, ...).
+func ZipIndexed[E any](rfns ...iter.Seq[E]) iter.Seq2[int, []E] {
+	return func(yield func(int, []E) bool) {
+		i := 0
+		for row := range Zip(rfns...) {
+			if !yield(i, row) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// ZipOptions holds the options for [ZipOpt]. The zero value gives the
+// same behavior as [Zip].
+type ZipOptions[E any] struct {
+	// Longest means continue while any iterator has elements (like
+	// [ZipLongest]) rather than stopping when the first runs out.
+	Longest bool
+	// Fill is used in place of the elements of exhausted iterators when
+	// Longest is true.
+	Fill E
+	// Strict means report an error wrapping [ErrLengthMismatch] if the
+	// iterators don't all yield the same number of elements. It takes
+	// precedence over Longest.
+	Strict bool
+	// ReuseRow means yield the same row slice every time (like
+	// [ZipReuse]).
+	ReuseRow bool
+}
+
+// ZipOpt accepts any number of iterators (rangefuncs) and returns a single
+// iterator that returns a slice of all the first elements from all the
+// iterators, then a slice of all the second elements, and so on, with a
+// nil error, according to the given options. In strict mode, if some
+// iterators run out before others, a final nil row and an error wrapping
+// [ErrLengthMismatch] are yielded instead of the incomplete row.
+//
+//	for row, err := range ZipOpt(ZipOptions[int]{Strict: true}, a, b) {
+//		if err != nil {
+//			return err // a and b are misaligned
+//		}
+//
+// See also [Zip], [ZipLongest], [ZipReuse], and [ZipStrict]
+func ZipOpt[E any](opts ZipOptions[E], rfns ...iter.Seq[E],
+) iter.Seq2[[]E, error] {
+	return func(yield func([]E, error) bool) {
 		pulls := make([]func() (E, bool), 0, len(rfns))
 		for _, rfn := range rfns {
 			pull, stop := iter.Pull(rfn)
 			defer stop()
 			pulls = append(pulls, pull)
 		}
-		for {
-			row := make([]E, 0, len(pulls))
-			for _, pull := range pulls {
+		var row []E
+		for n := 0; ; n++ {
+			if row == nil || !opts.ReuseRow {
+				row = make([]E, len(pulls))
+			}
+			oks := 0
+			for i, pull := range pulls {
 				if element, ok := pull(); ok {
-					row = append(row, element)
+					oks++
+					row[i] = element
+				} else if opts.Longest || opts.Strict {
+					row[i] = opts.Fill
 				} else {
 					return // finish when first of rfns is done
 				}
 			}
-			if !yield(row) { // yield complete rows only
+			if oks == 0 {
+				return
+			}
+			if oks < len(pulls) && opts.Strict {
+				yield(nil, fmt.Errorf("%w: at row %d", ErrLengthMismatch,
+					n))
+				return
+			}
+			if !yield(row, nil) { // yield complete rows only
 				return
 			}
 		}
@@ -207,28 +1777,149 @@ func Zip[E any](rfns ...iter.Seq[E]) iter.Seq[[]E] {
 // continues so long as at least one iterator has values, with exhausted
 // iterators' elements being replaced with their zero values.
 func ZipLongest[E any](rfns ...iter.Seq[E]) iter.Seq[[]E] {
+	return zipRows(ZipOpt(ZipOptions[E]{Longest: true}, rfns...))
+}
+
+// ZipReuse is like [Zip] except that it yields the same row slice every
+// time, overwriting its elements for each new row. This avoids allocating
+// a new slice per row, but means that a row is only valid until the next
+// one is yielded: copy it (e.g., with [slices.Clone]) to keep it.
+func ZipReuse[E any](rfns ...iter.Seq[E]) iter.Seq[[]E] {
+	return zipRows(ZipOpt(ZipOptions[E]{ReuseRow: true}, rfns...))
+}
+
+// ZipSeq2 returns an iterator which yields the first elements of a and b,
+// then their second elements, and so on, stopping as soon as either runs
+// out. Unlike [Zip] the iterators may be of different types, and since no
+// row slice is allocated ZipSeq2 is much cheaper for zipping two iterators.
+// See also [PairsToSeq2]
+func ZipSeq2[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for x := range a {
+			y, ok := next()
+			if !ok || !yield(x, y) {
+				return
+			}
+		}
+	}
+}
+
+// ZipStrict is like [Zip] except that if the iterators don't all yield the
+// same number of elements, instead of silently stopping at the shortest, it
+// yields a final nil row and an error wrapping [ErrLengthMismatch] (like
+// Python's zip(strict=True)). Every complete row is yielded with a nil
+// error.
+// See also [ZipOpt]
+func ZipStrict[E any](rfns ...iter.Seq[E]) iter.Seq2[[]E, error] {
+	return ZipOpt(ZipOptions[E]{Strict: true}, rfns...)
+}
+
+func zipRows[E any](seq iter.Seq2[[]E, error]) iter.Seq[[]E] {
 	return func(yield func([]E) bool) {
-		pulls := make([]func() (E, bool), 0, len(rfns))
-		for _, rfn := range rfns {
-			pull, stop := iter.Pull(rfn)
-			defer stop()
-			pulls = append(pulls, pull)
+		for row := range seq { // errors only occur in strict mode
+			if !yield(row) {
+				return
+			}
 		}
-		var zero E
-		for {
-			row := make([]E, 0, len(pulls))
-			oks := 0
-			for _, pull := range pulls {
-				if element, ok := pull(); ok {
-					oks++
-					row = append(row, element)
-				} else {
-					row = append(row, zero)
+	}
+}
+
+func keySet[E any, K comparable](values []E, key func(E) K) map[K]bool {
+	keys := make(map[K]bool, len(values))
+	for _, x := range values {
+		keys[key(x)] = true
+	}
+	return keys
+}
+
+func bestBy[E any, K cmp.Ordered](seq iter.Seq[E], score func(E) K,
+	better func(a, b K) bool,
+) (E, bool) {
+	var best E
+	var bestScore K
+	found := false
+	for x := range seq {
+		if s := score(x); !found || better(s, bestScore) {
+			best, bestScore, found = x, s, true
+		}
+	}
+	return best, found
+}
+
+func isFloat[N unum.SignedNumber]() bool { return N(1)/N(2) != 0 }
+
+// rangeCount returns how many of the values start + i×step (for i = 0, 1,
+// …) come before end, and whether end is itself a whole number of steps
+// (to within a tiny fraction of a step) from start.
+func rangeCount(start, end, step float64) (int, bool) {
+	steps := (end - start) / step
+	if math.IsNaN(steps) {
+		return 0, false
+	}
+	if n := math.Round(steps); n >= 0 && math.Abs(steps-n) <= 1e-9 {
+		return int(n), true
+	}
+	if steps < 0 {
+		return 0, false
+	}
+	if steps >= math.MaxInt {
+		return math.MaxInt, false
+	}
+	return int(math.Ceil(steps)), false
+}
+
+func mustRange[N any](seq iter.Seq[N], err error) iter.Seq[N] {
+	if err != nil {
+		panic(err.Error())
+	}
+	return seq
+}
+
+func rangeX[N unum.SignedNumber](start, end, step N,
+	inclusive bool,
+) (iter.Seq[N], error) {
+	if step == 0 {
+		return nil, ErrInvalidStep
+	}
+	ascending := start <= end
+	if step < 0 {
+		ascending = false
+	} else if !ascending {
+		step = -step
+	}
+	if isFloat[N]() {
+		count, exact := rangeCount(float64(start), float64(end),
+			float64(step))
+		if inclusive && exact {
+			count++
+		}
+		return func(yield func(N) bool) {
+			for i := range count {
+				x := start + N(i)*step
+				if inclusive && exact && i == count-1 {
+					x = end
 				}
+				if !yield(x) {
+					return
+				}
+			}
+		}, nil
+	}
+	return func(yield func(N) bool) {
+		if step < 0 && start < end {
+			return // descending step but ascending bounds
+		}
+		for x := start; ; x += step {
+			if inclusive && x == end {
+				yield(end)
+				return
 			}
-			if oks == 0 || !yield(row) {
+			if ascending && x >= end || !ascending && x <= end ||
+				!yield(x) {
 				return
 			}
 		}
-	}
+	}, nil
 }
@@ -1,10 +1,15 @@
 package ufunc
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark-summerfield/unum"
 )
@@ -173,6 +178,86 @@ func Test_Span(t *testing.T) {
 	}
 }
 
+func Test_SpansIndexed(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	out := []string{}
+	for start, end := range SpanBounds(len(data), 3) {
+		out = append(out, fmt.Sprintf("%d:%d", start, end))
+	}
+	if got := strings.Join(out, " "); got != "0:3 3:6 6:7" {
+		t.Errorf("expected 0:3 3:6 6:7, got %s", got)
+	}
+	squares := make([]int, len(data))
+	for start, span := range SpansIndexed(data, 3) {
+		for i, x := range span {
+			squares[start+i] = x * x
+		}
+	}
+	if exp := []int{1, 4, 9, 16, 25, 36, 49}; !slices.Equal(exp,
+		squares) {
+		t.Errorf("expected %v, got %v", exp, squares)
+	}
+	for range SpansIndexed([]int{}, 2) {
+		t.Error("expected no spans")
+	}
+	defer func() {
+		if r := recover(); r != ErrInvalidStride {
+			t.Errorf("expected ErrInvalidStride panic, got %v", r)
+		}
+	}()
+	SpansIndexed(data, 0)
+}
+
+func Test_SpansPaddedStrict(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	padded := slices.Collect(SpansPadded(data, 3, 0))
+	if got := fmt.Sprint(padded); got != "[[1 2 3] [4 5 6] [7 0 0]]" {
+		t.Errorf("expected [[1 2 3] [4 5 6] [7 0 0]], got %s", got)
+	}
+	if data[6] != 7 || len(data) != 7 {
+		t.Errorf("expected data unchanged, got %v", data)
+	}
+	strict := slices.Collect(SpansStrict(data, 3))
+	if got := fmt.Sprint(strict); got != "[[1 2 3] [4 5 6]]" {
+		t.Errorf("expected [[1 2 3] [4 5 6]], got %s", got)
+	}
+	strict = slices.Collect(SpansStrict(data[:6], 3))
+	if got := fmt.Sprint(strict); got != "[[1 2 3] [4 5 6]]" {
+		t.Errorf("expected [[1 2 3] [4 5 6]], got %s", got)
+	}
+	padded = slices.Collect(SpansPadded(data[:2], 3, -1))
+	if got := fmt.Sprint(padded); got != "[[1 2 -1]]" {
+		t.Errorf("expected [[1 2 -1]], got %s", got)
+	}
+}
+
+func Test_SpansX(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	for _, c := range []struct {
+		size, step int
+		exp        string
+	}{
+		{3, 3, "[1 2 3]:true [4 5 6]:true [7]:false"},
+		{3, 2, "[1 2 3]:true [3 4 5]:true [5 6 7]:true"},
+		{4, 2, "[1 2 3 4]:true [3 4 5 6]:true [5 6 7]:false"},
+		{2, 3, "[1 2]:true [4 5]:true [7]:false"},
+		{1, 4, "[1]:true [5]:true"},
+		{9, 1, "[1 2 3 4 5 6 7]:false"},
+	} {
+		out := []string{}
+		for span, ok := range SpansX(data, c.size, c.step) {
+			out = append(out, fmt.Sprintf("%v:%t", span, ok))
+		}
+		if got := strings.Join(out, " "); got != c.exp {
+			t.Errorf("size=%d step=%d: expected %s, got %s", c.size,
+				c.step, c.exp, got)
+		}
+	}
+	for range SpansX([]int{}, 2, 1) {
+		t.Error("expected no spans")
+	}
+}
+
 func Test_Range_int(t *testing.T) {
 	ints := make([]int, 0, 10)
 	// tag::range_int[]
@@ -373,3 +458,1245 @@ func Test_ZipLongest(t *testing.T) {
 		}
 	}
 }
+
+func Test_ZipOpt(t *testing.T) {
+	a := []int{1, 2, 3}
+	b := []int{4, 5}
+	out := []string{}
+	for row, err := range ZipOpt(ZipOptions[int]{Longest: true, Fill: -1},
+		slices.Values(a), slices.Values(b)) {
+		out = append(out, fmt.Sprint(row, err))
+	}
+	if got := strings.Join(out, " "); got !=
+		"[1 4] <nil> [2 5] <nil> [3 -1] <nil>" {
+		t.Errorf("unexpected rows %s", got)
+	}
+	out = out[:0]
+	var err error
+	for row, e := range ZipOpt(ZipOptions[int]{Strict: true},
+		slices.Values(a), slices.Values(b)) {
+		if e != nil {
+			err = e
+			break
+		}
+		out = append(out, fmt.Sprint(row))
+	}
+	if got := strings.Join(out, " "); got != "[1 4] [2 5]" {
+		t.Errorf("expected [1 4] [2 5], got %s", got)
+	}
+	if !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("expected ErrLengthMismatch, got %v", err)
+	}
+	count := 0
+	for _, err := range ZipOpt(ZipOptions[int]{Strict: true, ReuseRow: true},
+		slices.Values(a), slices.Values(a)) {
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("expected 3 rows, got %d", count)
+	}
+}
+
+func Test_ZipReuse(t *testing.T) {
+	var sums []int
+	var first []int
+	for row := range ZipReuse(RangeX(0, 11, 3), RangeX(1, 11, 3)) {
+		if first == nil {
+			first = row
+		}
+		sums = append(sums, row[0]+row[1])
+	}
+	if exp := []int{1, 7, 13, 19}; !slices.Equal(exp, sums) {
+		t.Errorf("expected %v, got %v", exp, sums)
+	}
+	if exp := []int{9, 10}; !slices.Equal(exp, first) {
+		t.Errorf("expected reused row %v, got %v", exp, first)
+	}
+	for range ZipReuse[int]() {
+		t.Error("expected no rows")
+	}
+}
+
+func Test_ZipSeq2(t *testing.T) {
+	out := []string{}
+	for word, n := range ZipSeq2(slices.Values([]string{"a", "b", "c"}),
+		RangeFrom(1, 1)) {
+		out = append(out, fmt.Sprintf("%s%d", word, n))
+	}
+	if got := strings.Join(out, " "); got != "a1 b2 c3" {
+		t.Errorf("expected a1 b2 c3, got %s", got)
+	}
+	out = out[:0]
+	for i, row := range ZipIndexed(slices.Values([]string{"x", "y"}),
+		slices.Values([]string{"p", "q", "r"})) {
+		out = append(out, fmt.Sprintf("%d%v", i, row))
+	}
+	if got := strings.Join(out, " "); got != "0[x p] 1[y q]" {
+		t.Errorf("expected 0[x p] 1[y q], got %s", got)
+	}
+}
+
+func Test_ZipStrict(t *testing.T) {
+	rows := [][]string{}
+	for row, err := range ZipStrict(slices.Values([]string{"a", "b"}),
+		slices.Values([]string{"x", "y"})) {
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		rows = append(rows, row)
+	}
+	if got := fmt.Sprint(rows); got != "[[a x] [b y]]" {
+		t.Errorf("expected [[a x] [b y]], got %s", got)
+	}
+	var err error
+	n := 0
+	for row, e := range ZipStrict(RangeX(0, 5, 1), RangeX(0, 3, 1)) {
+		if e != nil {
+			if row != nil {
+				t.Errorf("expected nil row with error, got %v", row)
+			}
+			err = e
+		} else {
+			n++
+		}
+	}
+	if n != 3 || !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("expected 3 ErrLengthMismatch, got %d %v", n, err)
+	}
+}
+
+func Test_MapFilterIndexed(t *testing.T) {
+	lines := []string{"name", "ann", "bob", "cy", "di"}
+	rows := slices.Collect(FilterIndexed(lines, func(i int, _ string) bool {
+		return i > 0
+	}))
+	if exp := []string{"ann", "bob", "cy", "di"}; !slices.Equal(exp, rows) {
+		t.Errorf("expected %v, got %v", exp, rows)
+	}
+	styled := slices.Collect(MapIndexed(rows, func(i int, s string) (string,
+		bool) {
+		if i%2 == 1 {
+			return strings.ToUpper(s), true
+		}
+		return s, len(s) > 2
+	}))
+	if exp := []string{"ann", "BOB", "DI"}; !slices.Equal(exp, styled) {
+		t.Errorf("expected %v, got %v", exp, styled)
+	}
+}
+
+func Test_Map2D(t *testing.T) {
+	grid := [][]int{{1, 2, 3}, {4}, {}}
+	strs := Map2D(grid, strconv.Itoa)
+	if got := fmt.Sprintf("%q", strs); got != `[["1" "2" "3"] ["4"] []]` {
+		t.Errorf(`expected [["1" "2" "3"] ["4"] []], got %s`, got)
+	}
+	out := []string{}
+	ForEach2D(grid, func(r, c, value int) {
+		out = append(out, fmt.Sprintf("%d,%d=%d", r, c, value))
+	})
+	if got := strings.Join(out, " "); got != "0,0=1 0,1=2 0,2=3 1,0=4" {
+		t.Errorf("expected 0,0=1 0,1=2 0,2=3 1,0=4, got %s", got)
+	}
+}
+
+func Test_Reduce1(t *testing.T) {
+	words := []string{"one", "two", "three"}
+	joined, ok := Reduce1(words, func(a, b string) string {
+		return a + ", " + b
+	})
+	if !ok || joined != "one, two, three" {
+		t.Errorf("expected one, two, three true, got %s %t", joined, ok)
+	}
+	if biggest, ok := Reduce1([]int{3, 9, 2}, func(a, b int) int {
+		return max(a, b)
+	}); !ok || biggest != 9 {
+		t.Errorf("expected 9 true, got %d %t", biggest, ok)
+	}
+	if got, ok := Reduce1([]int{}, func(a, b int) int { return a }); ok ||
+		got != 0 {
+		t.Errorf("expected 0 false, got %d %t", got, ok)
+	}
+	reversed := ReduceRight(words, func(s string, acc []string) []string {
+		return append(acc, s)
+	}, []string{})
+	if exp := []string{"three", "two", "one"}; !slices.Equal(exp,
+		reversed) {
+		t.Errorf("expected %v, got %v", exp, reversed)
+	}
+	if got := ReduceRight([]float64{2, 3, 64}, func(x, acc float64) float64 {
+		return acc / x
+	}, 1024); got != 8.0/3.0 {
+		t.Errorf("expected %g, got %g", 8.0/3.0, got)
+	}
+}
+
+func Test_RemoveAll(t *testing.T) {
+	a := []int{1, 2, 3, 2, 4, 2, 5}
+	exp := []int{1, 3, 4, 5}
+	if got := RemovedAll(a, 2); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if len(a) != 7 {
+		t.Errorf("expected original unchanged; got %v", a)
+	}
+	if got := RemoveAll(a, 2); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := RemoveAll([]int{}, 2); len(got) != 0 {
+		t.Errorf("expected [], got %v", got)
+	}
+}
+
+func Test_ReplaceAll(t *testing.T) {
+	a := []string{"a", "b", "a", "c"}
+	exp := []string{"x", "b", "x", "c"}
+	if got := ReplacedAll(a, "a", "x"); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if a[0] != "a" {
+		t.Errorf("expected original unchanged; got %v", a)
+	}
+	if got := ReplaceAll(a, "a", "x"); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if slices.Compare(a, exp) != 0 {
+		t.Errorf("expected in place %v, got %v", exp, a)
+	}
+}
+
+func Test_ReplaceAllSlice(t *testing.T) {
+	a := []int{1, 2, 3, 1, 2, 4, 1, 2}
+	exp := []int{9, 3, 9, 4, 9}
+	if got := ReplacedAllSlice(a, []int{1, 2}, []int{9}); slices.Compare(
+		got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	exp = []int{7, 8, 9, 3, 7, 8, 9, 4, 7, 8, 9}
+	if got := ReplaceAllSlice(a, []int{1, 2}, []int{7, 8, 9}); slices.Compare(
+		got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	exp = []int{9, 3, 9, 4, 9}
+	if got := ReplaceAllSlice(a, []int{1, 2}, []int{9}); slices.Compare(
+		got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if a[5] != 0 || a[7] != 0 {
+		t.Errorf("expected zeroed tail; got %v", a)
+	}
+	exp = []int{1, 2, 3}
+	if got := ReplaceAllSlice([]int{1, 2, 3}, nil, []int{9}); slices.Compare(
+		got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func Test_Rotate(t *testing.T) {
+	for _, x := range []struct {
+		n   int
+		exp []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}},
+		{2, []int{4, 5, 1, 2, 3}},
+		{-2, []int{3, 4, 5, 1, 2}},
+		{5, []int{1, 2, 3, 4, 5}},
+		{7, []int{4, 5, 1, 2, 3}},
+		{-11, []int{2, 3, 4, 5, 1}},
+	} {
+		a := []int{1, 2, 3, 4, 5}
+		if got := Rotated(a, x.n); slices.Compare(got, x.exp) != 0 {
+			t.Errorf("n=%d expected %v, got %v", x.n, x.exp, got)
+		}
+		Rotate(a, x.n)
+		if slices.Compare(a, x.exp) != 0 {
+			t.Errorf("n=%d expected %v, got %v", x.n, x.exp, a)
+		}
+	}
+	a := []int{1, 2, 3, 4, 5}
+	RotateLeft(a, 1)
+	exp := []int{2, 3, 4, 5, 1}
+	if slices.Compare(a, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, a)
+	}
+	RotateRight(a, 1)
+	exp = []int{1, 2, 3, 4, 5}
+	if slices.Compare(a, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, a)
+	}
+	Rotate([]int{}, 3) // mustn't panic
+}
+
+func Test_Transpose(t *testing.T) {
+	rows := [][]string{{"a", "b", "c"}, {"d", "e", "f"}}
+	cols, err := Transpose(rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := "[[a d] [b e] [c f]]"
+	if got := fmt.Sprintf("%v", cols); got != exp {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if _, err := Transpose([][]int{{1, 2}, {3}}); err != ErrRagged {
+		t.Errorf("expected ErrRagged, got %v", err)
+	}
+	if cols, err := Transpose([][]int{}); err != nil || len(cols) != 0 {
+		t.Errorf("expected [] <nil>, got %v %v", cols, err)
+	}
+}
+
+func Test_TransposeLongest(t *testing.T) {
+	rows := [][]int{{1, 2, 3}, {4}, {5, 6}}
+	exp := "[[1 4 5] [2 0 6] [3 0 0]]"
+	if got := fmt.Sprintf("%v", TransposeLongest(rows)); got != exp {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func Test_Flatten2D(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	rows := [][]int{}
+	for span := range Spans(data, 4) {
+		rows = append(rows, span)
+	}
+	flat := Flatten2D(rows)
+	if slices.Compare(flat, data) != 0 {
+		t.Errorf("expected %v, got %v", data, flat)
+	}
+	if cap(flat) != len(data) {
+		t.Errorf("expected cap %d, got %d", len(data), cap(flat))
+	}
+	if flat := Flatten2D([][]int{}); len(flat) != 0 {
+		t.Errorf("expected [], got %v", flat)
+	}
+}
+
+func Test_ExtendToLen(t *testing.T) {
+	a := []string{"a", "b"}
+	exp := []string{"a", "b", "-", "-", "-"}
+	if got := ExtendToLen(a, 5, "-"); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := ExtendToLen(a, 1, "-"); slices.Compare(got, a) != 0 {
+		t.Errorf("expected %v, got %v", a, got)
+	}
+}
+
+func Test_RepeatSlice(t *testing.T) {
+	exp := []int{1, 2, 1, 2, 1, 2}
+	if got := RepeatSlice([]int{1, 2}, 3); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := RepeatSlice([]int{1, 2}, 0); len(got) != 0 {
+		t.Errorf("expected [], got %v", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for negative count")
+		}
+	}()
+	RepeatSlice([]int{1}, -1)
+}
+
+func Test_InterleaveSlices(t *testing.T) {
+	a := []int{1, 4, 7, 10}
+	b := []int{2, 5, 8}
+	c := []int{3, 6, 9, 12, 15}
+	exp := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if got := InterleaveSlices(a, b, c); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	exp = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 12, 15}
+	if got := InterleaveSlicesLongest(a, b, c); slices.Compare(got,
+		exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got := InterleaveSlices[int](); len(got) != 0 {
+		t.Errorf("expected [], got %v", got)
+	}
+}
+
+func Test_SetOperations(t *testing.T) {
+	a := []int{5, 1, 3, 1, 7, 9}
+	b := []int{2, 3, 4, 9, 3, 6}
+	for _, x := range []struct {
+		name string
+		got  []int
+		exp  []int
+	}{
+		{"Union", Union(a, b), []int{5, 1, 3, 7, 9, 2, 4, 6}},
+		{"Intersect", Intersect(a, b), []int{3, 9}},
+		{"Difference", Difference(a, b), []int{5, 1, 7}},
+		{"SymmetricDifference", SymmetricDifference(a, b),
+			[]int{5, 1, 7, 2, 4, 6}},
+		{"Union empty", Union([]int{}, []int{}), []int{}},
+	} {
+		if slices.Compare(x.got, x.exp) != 0 {
+			t.Errorf("%s: expected %v, got %v", x.name, x.exp, x.got)
+		}
+	}
+}
+
+func Test_SetOperationsFunc(t *testing.T) {
+	a := []string{"Ant", "bee", "Cat"}
+	b := []string{"ant", "Dog", "cat", "dog"}
+	lower := strings.ToLower
+	for _, x := range []struct {
+		name string
+		got  []string
+		exp  []string
+	}{
+		{"UnionFunc", UnionFunc(a, b, lower),
+			[]string{"Ant", "bee", "Cat", "Dog"}},
+		{"IntersectFunc", IntersectFunc(a, b, lower),
+			[]string{"Ant", "Cat"}},
+		{"DifferenceFunc", DifferenceFunc(a, b, lower), []string{"bee"}},
+		{"SymmetricDifferenceFunc", SymmetricDifferenceFunc(a, b, lower),
+			[]string{"bee", "Dog"}},
+	} {
+		if slices.Compare(x.got, x.exp) != 0 {
+			t.Errorf("%s: expected %v, got %v", x.name, x.exp, x.got)
+		}
+	}
+}
+
+func Test_UniqueSlice(t *testing.T) {
+	a := []int{3, 1, 3, 2, 1, 4, 3}
+	exp := []int{3, 1, 2, 4}
+	if got := UniqueSlice(a); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	words := []string{"One", "two", "one", "Three", "TWO"}
+	expw := []string{"One", "two", "Three"}
+	if got := UniqueBy(words, strings.ToLower); slices.Compare(got,
+		expw) != 0 {
+		t.Errorf("expected %v, got %v", expw, got)
+	}
+}
+
+func Test_GroupBySlice(t *testing.T) {
+	words := []string{"ant", "bee", "ape", "cat", "bat", "asp"}
+	groups := GroupBySlice(words, func(s string) byte { return s[0] })
+	exp := "map[97:[ant ape asp] 98:[bee bat] 99:[cat]]"
+	if got := fmt.Sprintf("%v", groups); got != exp {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func Test_IndexBy(t *testing.T) {
+	words := []string{"ant", "bee", "ape", "cat"}
+	index := IndexBy(words, func(s string) byte { return s[0] })
+	exp := "map[97:ape 98:bee 99:cat]"
+	if got := fmt.Sprintf("%v", index); got != exp {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func Test_Buckets(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	buckets := Buckets(data, func(i int) string {
+		if i%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	exp := "map[even:[2 4 6 8 10] odd:[1 3 5 7 9]]"
+	if got := fmt.Sprintf("%v", buckets); got != exp {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	_ = append(buckets["even"], 12) // mustn't overwrite odd's elements
+	if got := fmt.Sprintf("%v", buckets); got != exp {
+		t.Errorf("expected %v after append, got %v", exp, got)
+	}
+}
+
+func Test_DistributeRoundRobin(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	exp := "[[1 4 7] [2 5 8] [3 6]]"
+	shards := DistributeRoundRobin(data, 3)
+	if got := fmt.Sprintf("%v", shards); got != exp {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	for _, shard := range shards {
+		if len(shard) != cap(shard) {
+			t.Errorf("expected exact capacity, got len %d cap %d",
+				len(shard), cap(shard))
+		}
+	}
+	exp = "[[1] [] []]"
+	if got := fmt.Sprintf("%v", DistributeRoundRobin([]int{1}, 3)); got != exp {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func Test_SplitAt(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	for _, x := range []struct {
+		i     int
+		left  string
+		right string
+	}{
+		{2, "[1 2]", "[3 4 5]"},
+		{0, "[]", "[1 2 3 4 5]"},
+		{5, "[1 2 3 4 5]", "[]"},
+		{9, "[1 2 3 4 5]", "[]"},
+		{-1, "[1 2 3 4]", "[5]"},
+		{-9, "[]", "[1 2 3 4 5]"},
+	} {
+		left, right := SplitAt(data, x.i)
+		if got := fmt.Sprint(left); got != x.left {
+			t.Errorf("i=%d expected %v, got %v", x.i, x.left, got)
+		}
+		if got := fmt.Sprint(right); got != x.right {
+			t.Errorf("i=%d expected %v, got %v", x.i, x.right, got)
+		}
+	}
+	left, right := Halve(data)
+	if got := fmt.Sprint(left, right); got != "[1 2] [3 4 5]" {
+		t.Errorf("expected [1 2] [3 4 5], got %v", got)
+	}
+	_ = append(left, 99) // left's capacity is clamped
+	if data[2] != 3 {
+		t.Errorf("expected 3, got %d", data[2])
+	}
+}
+
+func Test_HeadTailInitLastN(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	empty := []int{}
+	for _, x := range []struct {
+		name string
+		got  []int
+		exp  string
+	}{
+		{"Head 2", Head(data, 2), "[1 2]"},
+		{"Head 9", Head(data, 9), "[1 2 3 4 5]"},
+		{"Head -1", Head(data, -1), "[]"},
+		{"LastN 2", LastN(data, 2), "[4 5]"},
+		{"LastN 9", LastN(data, 9), "[1 2 3 4 5]"},
+		{"LastN -1", LastN(data, -1), "[]"},
+		{"Tail", Tail(data), "[2 3 4 5]"},
+		{"Tail empty", Tail(empty), "[]"},
+		{"Init", Init(data), "[1 2 3 4]"},
+		{"Init empty", Init(empty), "[]"},
+	} {
+		if got := fmt.Sprint(x.got); got != x.exp {
+			t.Errorf("%s: expected %v, got %v", x.name, x.exp, got)
+		}
+	}
+}
+
+func Test_Transform(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	Transform(data, func(i int) int { return i * i })
+	exp := []int{1, 4, 9, 16}
+	if slices.Compare(data, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, data)
+	}
+}
+
+func Test_MapTo(t *testing.T) {
+	buffer := make([]string, 0, 8)
+	data := []int{1, 2, 3}
+	got := MapTo(buffer, data, func(i int) string { return fmt.Sprint(-i) })
+	exp := []string{"-1", "-2", "-3"}
+	if slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if &got[0] != &buffer[:1][0] {
+		t.Error("expected buffer to be reused")
+	}
+	got = MapTo(got, []int{7}, func(i int) string { return fmt.Sprint(i) })
+	exp = []string{"7"}
+	if slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	got = MapTo(nil, data, func(i int) string { return fmt.Sprint(i) })
+	exp = []string{"1", "2", "3"}
+	if slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func Test_FilterSlice(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	isOdd := func(i int) bool { return i%2 != 0 }
+	exp := []int{1, 3, 5, 7}
+	if got := FilterSlice(data, isOdd); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if data[1] != 2 {
+		t.Errorf("expected original unchanged; got %v", data)
+	}
+	if got := FilterInPlace(data, isOdd); slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	exp = []int{1, 3, 5, 7, 0, 0, 0}
+	if slices.Compare(data, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, data)
+	}
+}
+
+func Test_Count(t *testing.T) {
+	//         0  1  2  3   4   5   6  7  8  9
+	a := []int{2, 4, 6, 8, 10, 12, 10, 8, 6, 4}
+	e := 2
+	if i := Count(a, 8); i != e {
+		t.Errorf("expected %d; got %d", e, i)
+	}
+	e = 0
+	if i := Count(a, 88); i != e {
+		t.Errorf("expected %d; got %d", e, i)
+	}
+	e = 4
+	if i := CountFunc(a, func(x int) bool { return x > 7 && x < 11 }); i != e {
+		t.Errorf("expected %d; got %d", e, i)
+	}
+}
+
+func Test_MaxByMinBy(t *testing.T) {
+	words := []string{"kiwi", "banana", "fig", "cherry", "pea"}
+	length := func(s string) int { return len(s) }
+	if w, ok := MaxBy(words, length); w != "banana" || !ok {
+		t.Errorf("expected banana true, got %s %t", w, ok)
+	}
+	if w, ok := MinBy(words, length); w != "fig" || !ok {
+		t.Errorf("expected fig true, got %s %t", w, ok)
+	}
+	if w, ok := MaxBySeq(slices.Values(words), strings.ToUpper); w != "pea" ||
+		!ok {
+		t.Errorf("expected pea true, got %s %t", w, ok)
+	}
+	if n, ok := MinBySeq(Range(-5, 5), func(i int) int {
+		return i * i
+	}); n != 0 || !ok {
+		t.Errorf("expected 0 true, got %d %t", n, ok)
+	}
+	if _, ok := MaxBy([]string{}, length); ok {
+		t.Error("expected false for empty")
+	}
+}
+
+func Test_Windows(t *testing.T) {
+	var windows [][]int
+	for w := range Windows([]int{1, 2, 3, 4, 5}, 3) {
+		windows = append(windows, w)
+	}
+	exp := "[[1 2 3] [2 3 4] [3 4 5]]"
+	if got := fmt.Sprint(windows); got != exp {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	for w := range Windows([]int{1, 2}, 3) {
+		t.Errorf("expected no windows, got %v", w)
+	}
+}
+
+func Test_WindowAggregate(t *testing.T) {
+	words := []string{"a", "b", "c", "d"}
+	got := WindowAggregate(words, 2, func(w []string) string {
+		return strings.Join(w, "")
+	})
+	exp := []string{"ab", "bc", "cd"}
+	if slices.Compare(got, exp) != 0 {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	maxes := WindowAggregate([]int{3, 1, 4, 1, 5, 9, 2}, 3, func(w []int) int {
+		return slices.Max(w)
+	})
+	expi := []int{4, 4, 5, 9, 9}
+	if slices.Compare(maxes, expi) != 0 {
+		t.Errorf("expected %v, got %v", expi, maxes)
+	}
+}
+
+func Test_Linspace(t *testing.T) {
+	got := slices.Collect(Linspace(0, 1, 5))
+	exp := []float64{0, 0.25, 0.5, 0.75, 1}
+	if !equalReals(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	got = slices.Collect(Linspace(0, 1, 11))
+	if len(got) != 11 || got[10] != 1 {
+		t.Errorf("expected 11 values ending 1, got %v", got)
+	}
+	got = slices.Collect(Linspace(5, -5, 3))
+	exp = []float64{5, 0, -5}
+	if !equalReals(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	got = slices.Collect(Linspace(2, 3, 1))
+	exp = []float64{2}
+	if !equalReals(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got = slices.Collect(Linspace(2, 3, 0)); len(got) != 0 {
+		t.Errorf("expected [], got %v", got)
+	}
+}
+
+func Test_Logspace(t *testing.T) {
+	got := slices.Collect(Logspace(0, 3, 4))
+	exp := []float64{1, 10, 100, 1000}
+	if !equalReals(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	defer func() {
+		if r := recover(); r != "count must be >= 0" {
+			t.Errorf("expected count panic, got %v", r)
+		}
+	}()
+	_ = Logspace(0, 3, -1) // must panic without being ranged over
+}
+
+func Test_RangeX_accuracy(t *testing.T) {
+	reals := slices.Collect(RangeX(0.0, 1.0, 0.1))
+	if len(reals) != 10 {
+		t.Errorf("expected 10 values, got %d: %v", len(reals), reals)
+	}
+	if x := reals[8]; x != 0.8 {
+		t.Errorf("expected 0.8, got %.17g", x)
+	}
+	count := 0
+	last := 0.0
+	for x := range RangeX(0.0, 100.0, 0.01) {
+		count++
+		last = x
+	}
+	if count != 10000 {
+		t.Errorf("expected 10000 values, got %d", count)
+	}
+	if !unum.IsClose(last, 99.99) {
+		t.Errorf("expected 99.99, got %.17g", last)
+	}
+	reals = slices.Collect(RangeX(1.0, 0.0, 0.1))
+	if len(reals) != 10 || !unum.IsClose(reals[9], 0.1) {
+		t.Errorf("expected 10 values ending 0.1, got %v", reals)
+	}
+	reals = slices.Collect(RangeX(0.0, 0.9, 0.3))
+	if rx := []float64{0, 0.3, 0.6}; !equalReals(rx, reals) {
+		t.Errorf("expected %v; got %v", rx, reals)
+	}
+	reals = slices.Collect(RangeX(0.7, 0.0, 0.1))
+	if len(reals) != 7 || !unum.IsClose(reals[6], 0.1) {
+		t.Errorf("expected 7 values ending 0.1, got %v", reals)
+	}
+	reals = slices.Collect(RangeX(0.0, 0.95, 0.3))
+	if rx := []float64{0, 0.3, 0.6, 0.9}; !equalReals(rx, reals) {
+		t.Errorf("expected %v; got %v", rx, reals)
+	}
+	seq := RangeX(0, 3, 1)
+	first := slices.Collect(seq)
+	second := slices.Collect(seq)
+	if slices.Compare(first, second) != 0 {
+		t.Errorf("expected reusable iterator; got %v then %v", first, second)
+	}
+}
+
+func Test_RangeIncl(t *testing.T) {
+	ints := slices.Collect(RangeIncl(5, 10))
+	ix := []int{5, 6, 7, 8, 9, 10}
+	if slices.Compare(ix, ints) != 0 {
+		t.Errorf("expected %v; got %v", ix, ints)
+	}
+	ints = slices.Collect(RangeXIncl(10, 0, 5))
+	ix = []int{10, 5, 0}
+	if slices.Compare(ix, ints) != 0 {
+		t.Errorf("expected %v; got %v", ix, ints)
+	}
+	ints = slices.Collect(RangeXIncl(0, 10, 3))
+	ix = []int{0, 3, 6, 9}
+	if slices.Compare(ix, ints) != 0 {
+		t.Errorf("expected %v; got %v", ix, ints)
+	}
+	ints = slices.Collect(RangeIncl(3, 3))
+	ix = []int{3}
+	if slices.Compare(ix, ints) != 0 {
+		t.Errorf("expected %v; got %v", ix, ints)
+	}
+	small := slices.Collect(RangeIncl[int8](125, 127))
+	if slices.Compare(small, []int8{125, 126, 127}) != 0 {
+		t.Errorf("expected [125 126 127]; got %v", small)
+	}
+	reals := slices.Collect(RangeXIncl(0.0, 0.3, 0.1))
+	rx := []float64{0, 0.1, 0.2, 0.3}
+	if !equalReals(rx, reals) || reals[3] != 0.3 {
+		t.Errorf("expected %v; got %v", rx, reals)
+	}
+	reals = slices.Collect(RangeXIncl(1.0, 0.0, 0.25))
+	rx = []float64{1, 0.75, 0.5, 0.25, 0}
+	if !equalReals(rx, reals) {
+		t.Errorf("expected %v; got %v", rx, reals)
+	}
+	reals = slices.Collect(RangeX(0.0, 0.9, 0.3))
+	rx = []float64{0, 0.3, 0.6}
+	if !equalReals(rx, reals) {
+		t.Errorf("expected %v; got %v", rx, reals)
+	}
+	reals = slices.Collect(RangeXIncl(0.7, 0.0, 0.1))
+	if len(reals) != 8 || reals[7] != 0 {
+		t.Errorf("expected 8 values ending 0, got %v", reals)
+	}
+	reals = slices.Collect(RangeXIncl(-0.3, 0.0, 0.1))
+	rx = []float64{-0.3, -0.2, -0.1, 0}
+	if !equalReals(rx, reals) || reals[3] != 0 {
+		t.Errorf("expected %v; got %v", rx, reals)
+	}
+	reals = slices.Collect(RangeXIncl(0.0, 0.9, 0.3))
+	rx = []float64{0, 0.3, 0.6, 0.9}
+	if !equalReals(rx, reals) || reals[3] != 0.9 {
+		t.Errorf("expected %v; got %v", rx, reals)
+	}
+	reals = slices.Collect(RangeXIncl(0.0, 0.95, 0.3))
+	rx = []float64{0, 0.3, 0.6, 0.9}
+	if !equalReals(rx, reals) {
+		t.Errorf("expected %v; got %v", rx, reals)
+	}
+	if reals = slices.Collect(RangeXIncl(0.0, 1.0, -0.5)); len(reals) != 0 {
+		t.Errorf("expected []; got %v", reals)
+	}
+}
+
+func Test_RangeN(t *testing.T) {
+	ints := slices.Collect(RangeN(10, 4, -3))
+	ix := []int{10, 7, 4, 1}
+	if slices.Compare(ix, ints) != 0 {
+		t.Errorf("expected %v; got %v", ix, ints)
+	}
+	reals := slices.Collect(RangeN(0.0, 100, 0.1))
+	if len(reals) != 100 || !unum.IsClose(reals[99], 9.9) {
+		t.Errorf("expected 100 values ending 9.9; got %d ending %v",
+			len(reals), reals[len(reals)-1])
+	}
+	if ints = slices.Collect(RangeN(1, 0, 1)); len(ints) != 0 {
+		t.Errorf("expected []; got %v", ints)
+	}
+}
+
+func Test_RangeXE_SpansE(t *testing.T) {
+	if seq, err := RangeXE(1, 10, 0); seq != nil || err != ErrInvalidStep {
+		t.Errorf("expected nil ErrInvalidStep, got %v", err)
+	}
+	seq, err := RangeXE(1, 10, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ix := []int{1, 5, 9}
+	if got := slices.Collect(seq); slices.Compare(ix, got) != 0 {
+		t.Errorf("expected %v; got %v", ix, got)
+	}
+	if spans, err := SpansE([]int{1, 2}, -1); spans != nil ||
+		err != ErrInvalidStride {
+		t.Errorf("expected nil ErrInvalidStride, got %v", err)
+	}
+	spans, err := SpansE([]int{1, 2, 3}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got [][]int
+	for span := range spans {
+		got = append(got, span)
+	}
+	exp := "[[1 2] [3]]"
+	if s := fmt.Sprint(got); s != exp {
+		t.Errorf("expected %v, got %v", exp, s)
+	}
+	func() {
+		defer func() {
+			if err := recover(); err != "stride must be > 0" {
+				t.Errorf("expected stride panic, got %v", err)
+			}
+		}()
+		Spans([]int{1, 2, 3}, 0)
+	}()
+	defer func() {
+		if err := recover(); err != "step size must be nonzero" {
+			t.Errorf("expected step size panic, got %v", err)
+		}
+	}()
+	RangeX(1.0, 2.0, 0)
+}
+
+func Test_RangeX_signed_step(t *testing.T) {
+	ints := slices.Collect(RangeX(10, 0, -3))
+	ix := []int{10, 7, 4, 1}
+	if slices.Compare(ix, ints) != 0 {
+		t.Errorf("expected %v; got %v", ix, ints)
+	}
+	if ints = slices.Collect(RangeX(0, 10, -3)); len(ints) != 0 {
+		t.Errorf("expected []; got %v", ints)
+	}
+	ints = slices.Collect(RangeX(10, 0, 3)) // direction inferred
+	ix = []int{10, 7, 4, 1}
+	if slices.Compare(ix, ints) != 0 {
+		t.Errorf("expected %v; got %v", ix, ints)
+	}
+	ints = slices.Collect(RangeXIncl(9, 0, -3))
+	ix = []int{9, 6, 3, 0}
+	if slices.Compare(ix, ints) != 0 {
+		t.Errorf("expected %v; got %v", ix, ints)
+	}
+	reals := slices.Collect(RangeX(1.0, 0.0, -0.25))
+	rx := []float64{1, 0.75, 0.5, 0.25}
+	if !equalReals(rx, reals) {
+		t.Errorf("expected %v; got %v", rx, reals)
+	}
+	if _, err := RangeXE(0, 10, -1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_RangeUint(t *testing.T) {
+	got := slices.Collect(RangeUint(uint64(5), 8))
+	exp := []uint64{5, 6, 7}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	bytes := slices.Collect(RangeUintX(uint8(250), 3, 100))
+	expb := []uint8{250, 150, 50}
+	if slices.Compare(expb, bytes) != 0 {
+		t.Errorf("expected %v; got %v", expb, bytes)
+	}
+	bytes = slices.Collect(RangeUintX(uint8(200), 255, 50))
+	expb = []uint8{200, 250}
+	if slices.Compare(expb, bytes) != 0 {
+		t.Errorf("expected %v; got %v", expb, bytes)
+	}
+	bytes = slices.Collect(RangeUintX(uint8(5), 0, 1))
+	expb = []uint8{5, 4, 3, 2, 1}
+	if slices.Compare(expb, bytes) != 0 {
+		t.Errorf("expected %v; got %v", expb, bytes)
+	}
+}
+
+func Test_RangeBig(t *testing.T) {
+	huge := new(big.Int).Lsh(big.NewInt(1), 100)
+	end := new(big.Int).Add(huge, big.NewInt(7))
+	var got []string
+	for x := range RangeBig(huge, end, big.NewInt(3)) {
+		got = append(got, new(big.Int).Sub(x, huge).String())
+	}
+	exp := []string{"0", "3", "6"}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = got[:0]
+	for x := range RangeBig(big.NewInt(5), big.NewInt(0), big.NewInt(-2)) {
+		got = append(got, x.String())
+	}
+	exp = []string{"5", "3", "1"}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	values := slices.Collect(RangeBig(big.NewInt(0), big.NewInt(3),
+		big.NewInt(1)))
+	if values[0].Int64() != 0 || values[2].Int64() != 2 {
+		t.Errorf("expected distinct values; got %v", values)
+	}
+}
+
+func Test_RangeTime(t *testing.T) {
+	friday := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	var days []string
+	for day := range RangeTime(friday, friday.AddDate(0, 0, 4),
+		24*time.Hour) {
+		days = append(days, day.Format("Mon 02"))
+	}
+	exp := []string{"Fri 16", "Sat 17", "Sun 18", "Mon 19"}
+	if slices.Compare(exp, days) != 0 {
+		t.Errorf("expected %v; got %v", exp, days)
+	}
+	days = days[:0]
+	for day := range RangeTimeFunc(friday, friday.AddDate(0, 0, 5),
+		24*time.Hour, IsWeekday) {
+		days = append(days, day.Format("Mon 02"))
+	}
+	exp = []string{"Fri 16", "Mon 19", "Tue 20"}
+	if slices.Compare(exp, days) != 0 {
+		t.Errorf("expected %v; got %v", exp, days)
+	}
+	days = days[:0]
+	for hour := range RangeTime(friday, friday.Add(-3*time.Hour),
+		time.Hour) {
+		days = append(days, hour.Format("15"))
+	}
+	exp = []string{"09", "08", "07"}
+	if slices.Compare(exp, days) != 0 {
+		t.Errorf("expected %v; got %v", exp, days)
+	}
+}
+
+func Test_RangeFrom(t *testing.T) {
+	var odds []int
+	for i := range RangeFrom(1, 2) {
+		if i > 10 {
+			break
+		}
+		odds = append(odds, i)
+	}
+	exp := []int{1, 3, 5, 7, 9}
+	if slices.Compare(exp, odds) != 0 {
+		t.Errorf("expected %v; got %v", exp, odds)
+	}
+	var rows [][]float64
+	for row := range Zip(RangeFrom(0.0, -0.5), Range(0.0, 3.0)) {
+		rows = append(rows, row)
+	}
+	expr := "[[0 0] [-0.5 1] [-1 2]]"
+	if got := fmt.Sprint(rows); got != expr {
+		t.Errorf("expected %v; got %v", expr, got)
+	}
+}
+
+func Test_Letters(t *testing.T) {
+	got := slices.Collect(Letters('a', 'e'))
+	exp := []string{"a", "b", "c", "d", "e"}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = slices.Collect(Strings(slices.Values([]rune("αβγ"))))
+	exp = []string{"α", "β", "γ"}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+}
+
+func Test_Labels(t *testing.T) {
+	var labels []string
+	for label := range Labels('A', 'Z') {
+		labels = append(labels, label)
+		if len(labels) == 26*27+1 {
+			break
+		}
+	}
+	for i, exp := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB",
+		51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if labels[i] != exp {
+			t.Errorf("expected %d:%s; got %s", i, exp, labels[i])
+		}
+	}
+	labels = labels[:0]
+	for label := range Labels('0', '1') {
+		labels = append(labels, label)
+		if len(labels) == 7 {
+			break
+		}
+	}
+	exp := []string{"0", "1", "00", "01", "10", "11", "000"}
+	if slices.Compare(exp, labels) != 0 {
+		t.Errorf("expected %v; got %v", exp, labels)
+	}
+}
+
+func Test_Indices2D(t *testing.T) {
+	var got []string
+	for row, col := range Indices2D(2, 3) {
+		got = append(got, fmt.Sprintf("%d,%d", row, col))
+	}
+	exp := []string{"0,0", "0,1", "0,2", "1,0", "1,1", "1,2"}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	got = got[:0]
+	for row, col := range Indices2DX(5, 4, 2, 3) {
+		got = append(got, fmt.Sprintf("%d,%d", row, col))
+	}
+	exp = []string{"0,0", "0,3", "2,0", "2,3", "4,0", "4,3"}
+	if slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	for row, col := range Indices2D(0, 3) {
+		t.Errorf("expected nothing; got %d,%d", row, col)
+	}
+	triples := slices.Collect(Indices3D(2, 1, 2))
+	expt := "[[0 0 0] [0 0 1] [1 0 0] [1 0 1]]"
+	if s := fmt.Sprint(triples); s != expt {
+		t.Errorf("expected %v; got %v", expt, s)
+	}
+}
+
+func Test_Fold(t *testing.T) {
+	words := []string{"GET", "/Users", "ID", "get"}
+	if !HasPrefixFold(words, []string{"get", "/users"}) {
+		t.Error("expected true; got false")
+	}
+	if HasPrefixFold(words, []string{"post"}) {
+		t.Error("expected false; got true")
+	}
+	if !HasSuffixFold(words, []string{"id", "Get"}) {
+		t.Error("expected true; got false")
+	}
+	if HasSuffixFold(words[:1], []string{"id", "get"}) {
+		t.Error("expected false; got true")
+	}
+	if i := LastIndexFold(words, "Get"); i != 3 {
+		t.Errorf("expected 3; got %d", i)
+	}
+	if i := LastIndexFold(words, "put"); i != -1 {
+		t.Errorf("expected -1; got %d", i)
+	}
+}
+
+func Test_HasPrefixFunc(t *testing.T) {
+	reals := []float64{1.0, 2.0000000001, 3.0}
+	if !HasPrefixFunc(reals, []float64{1, 2}, unum.IsClose) {
+		t.Error("expected true; got false")
+	}
+	if !HasSuffixFunc(reals, []float64{2, 3}, unum.IsClose) {
+		t.Error("expected true; got false")
+	}
+	if HasSuffixFunc(reals, []float64{2.1, 3}, unum.IsClose) {
+		t.Error("expected false; got true")
+	}
+}
+
+func Test_HasAnyPrefix(t *testing.T) {
+	tokens := []string{"git", "remote", "add", "origin"}
+	commands := [][]string{{"git", "push"}, {"git", "remote", "add"},
+		{"git", "remote"}}
+	if i := IndexAnyPrefix(tokens, commands...); i != 1 {
+		t.Errorf("expected 1; got %d", i)
+	}
+	if !HasAnyPrefix(tokens, commands...) {
+		t.Error("expected true; got false")
+	}
+	if HasAnyPrefix(tokens, []string{"svn"}) || HasAnyPrefix(tokens) {
+		t.Error("expected false; got true")
+	}
+	if i := IndexAnySuffix(tokens, []string{"add"},
+		[]string{"add", "origin"}); i != 1 {
+		t.Errorf("expected 1; got %d", i)
+	}
+	if !HasAnySuffix(tokens, []string{"upstream"}, []string{"origin"}) {
+		t.Error("expected true; got false")
+	}
+	if i := IndexAnySuffix(tokens, []string{"upstream"}); i != -1 {
+		t.Errorf("expected -1; got %d", i)
+	}
+}
+
+func Test_IndexAll(t *testing.T) {
+	//              0    1    2    3    4    5    6
+	a := []string{"a", ",", "b", ",", ",", "c", ","}
+	exp := []int{1, 3, 4, 6}
+	if got := IndexAll(a, ","); slices.Compare(exp, got) != 0 {
+		t.Errorf("expected %v; got %v", exp, got)
+	}
+	if got := IndexAll(a, ";"); len(got) != 0 {
+		t.Errorf("expected []; got %v", got)
+	}
+	for n, e := range map[int]int{0: 1, 1: 3, 3: 6, 4: -1, -1: 6, -2: 4,
+		-4: 1, -5: -1} {
+		if i := NthIndex(a, ",", n); i != e {
+			t.Errorf("n=%d expected %d; got %d", n, e, i)
+		}
+	}
+}
+
+func Test_FindLast(t *testing.T) {
+	words := []string{"apple", "banana", "avocado", "cherry"}
+	startsA := func(s string) bool { return strings.HasPrefix(s, "a") }
+	if w, ok := FindLast(words, startsA); w != "avocado" || !ok {
+		t.Errorf("expected avocado true; got %s %t", w, ok)
+	}
+	if w, ok := FindLastSeq(slices.Values(words), startsA); w != "avocado" ||
+		!ok {
+		t.Errorf("expected avocado true; got %s %t", w, ok)
+	}
+	isEmpty := func(s string) bool { return s == "" }
+	if w, ok := FindLast(words, isEmpty); w != "" || ok {
+		t.Errorf("expected \"\" false; got %q %t", w, ok)
+	}
+	if _, ok := FindLastSeq(slices.Values(words), isEmpty); ok {
+		t.Error("expected false; got true")
+	}
+}
+
+func Test_InsertSorted(t *testing.T) {
+	var values []int
+	for _, x := range []int{5, 1, 4, 1, 9, 2} {
+		values = InsertSorted(values, x)
+	}
+	exp := []int{1, 1, 2, 4, 5, 9}
+	if slices.Compare(exp, values) != 0 {
+		t.Errorf("expected %v; got %v", exp, values)
+	}
+	byFold := func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+	words := []string{}
+	for _, w := range []string{"Cat", "ant", "Bee"} {
+		words = InsertSortedFunc(words, w, byFold)
+	}
+	expw := []string{"ant", "Bee", "Cat"}
+	if slices.Compare(expw, words) != 0 {
+		t.Errorf("expected %v; got %v", expw, words)
+	}
+}
+
+func Test_MergeSortedSlices(t *testing.T) {
+	merged := MergeSortedSlices([]int{1, 4, 4, 9}, []int{0, 4, 5, 10, 11})
+	exp := []int{0, 1, 4, 4, 4, 5, 9, 10, 11}
+	if slices.Compare(exp, merged) != 0 {
+		t.Errorf("expected %v; got %v", exp, merged)
+	}
+	type item struct {
+		key  int
+		name string
+	}
+	byKey := func(a, b item) int { return a.key - b.key }
+	items := MergeSortedSlicesFunc([]item{{1, "a1"}, {2, "a2"}},
+		[]item{{1, "b1"}, {3, "b3"}}, byKey)
+	expi := "[{1 a1} {1 b1} {2 a2} {3 b3}]"
+	if got := fmt.Sprint(items); got != expi {
+		t.Errorf("expected %v; got %v", expi, got)
+	}
+	if got := MergeSortedSlices([]int{}, []int{2}); slices.Compare(got,
+		[]int{2}) != 0 {
+		t.Errorf("expected [2]; got %v", got)
+	}
+}
+
+func Test_PartitionPoint(t *testing.T) {
+	sorted := []int{1, 3, 5, 7, 9, 11}
+	for limit, exp := range map[int]int{0: 0, 1: 0, 6: 3, 9: 4, 12: 6} {
+		below := func(x int) bool { return x < limit }
+		if i := PartitionPoint(sorted, below); i != exp {
+			t.Errorf("limit=%d expected %d; got %d", limit, exp, i)
+		}
+		if i := PartitionPointSeq(slices.Values(sorted), below); i != exp {
+			t.Errorf("limit=%d expected %d; got %d", limit, exp, i)
+		}
+	}
+	// smallest n whose square is >= 1e12
+	if n := PartitionPointFunc(10_000_000, func(n int) bool {
+		return n*n < 1_000_000_000_000
+	}); n != 1_000_000 {
+		t.Errorf("expected 1000000; got %d", n)
+	}
+	if i := PartitionPoint([]int{}, func(int) bool { return true }); i != 0 {
+		t.Errorf("expected 0; got %d", i)
+	}
+}
+
+func Test_ElementsMatch(t *testing.T) {
+	if !ElementsMatch([]int{1, 2, 2, 3}, []int{2, 3, 1, 2}) {
+		t.Error("expected true; got false")
+	}
+	if ElementsMatch([]int{1, 2, 2, 3}, []int{1, 2, 3, 3}) {
+		t.Error("expected false; got true")
+	}
+	if !ElementsMatch([]string{}, nil) {
+		t.Error("expected true; got false")
+	}
+	missing, extra := ElementsMatchDiff([]int{1, 2, 2, 3, 4},
+		[]int{4, 5, 2, 1, 5})
+	if exp := []int{2, 3}; slices.Compare(exp, missing) != 0 {
+		t.Errorf("expected missing %v; got %v", exp, missing)
+	}
+	if exp := []int{5, 5}; slices.Compare(exp, extra) != 0 {
+		t.Errorf("expected extra %v; got %v", exp, extra)
+	}
+}